@@ -37,6 +37,14 @@ var (
 			signUpTOTP:                   "auth/totp/signup",
 			updateTOTP:                   "auth/totp/update",
 			verifyTOTPCode:               "auth/totp/verify",
+			signUpPassword:               "auth/password/signup",
+			signInPassword:               "auth/password/signin",
+			sendPasswordReset:            "auth/password/reset",
+			updateUserPassword:           "auth/password/update",
+			replaceUserPassword:          "auth/password/replace",
+			passwordPolicy:               "auth/password/policy",
+			flowStart:                    "flow/start",
+			flowNext:                     "flow/next",
 			verifyCode:                   "auth/otp/verify",
 			signInMagicLink:              "auth/magiclink/signin",
 			signUpMagicLink:              "auth/magiclink/signup",
@@ -52,6 +60,9 @@ var (
 			exchangeTokenOAuth:           "auth/oauth/exchange",
 			samlStart:                    "auth/saml/authorize",
 			exchangeTokenSAML:            "auth/saml/exchange",
+			ssoStart:                     "auth/sso/authorize",
+			exchangeTokenSSO:             "auth/sso/exchange",
+			ssoTenant:                    "auth/sso/tenant",
 			webauthnSignUpStart:          "auth/webauthn/signup/start",
 			webauthnSignUpFinish:         "auth/webauthn/signup/finish",
 			webauthnSignInStart:          "auth/webauthn/signin/start",
@@ -66,45 +77,72 @@ var (
 			exchangeAccessKey:            "auth/accesskey/exchange",
 		},
 		mgmt: mgmtEndpoints{
-			tenantCreate:                "mgmt/tenant/create",
-			tenantUpdate:                "mgmt/tenant/update",
-			tenantDelete:                "mgmt/tenant/delete",
-			tenantLoadAll:               "mgmt/tenant/all",
-			userCreate:                  "mgmt/user/create",
-			userUpdate:                  "mgmt/user/update",
-			userDelete:                  "mgmt/user/delete",
-			userLoad:                    "mgmt/user",
-			userSearchAll:               "mgmt/user/search",
-			userUpdateStatus:            "mgmt/user/update/status",
-			userUpdateEmail:             "mgmt/user/update/email",
-			userUpdatePhone:             "mgmt/user/update/phone",
-			userUpdateName:              "mgmt/user/update/name",
-			userAddTenant:               "mgmt/user/update/tenant/add",
-			userRemoveTenant:            "mgmt/user/update/tenant/remove",
-			userAddRole:                 "mgmt/user/update/role/add",
-			userRemoveRole:              "mgmt/user/update/role/remove",
-			accessKeyCreate:             "mgmt/accesskey/create",
-			accessKeyLoad:               "mgmt/accesskey",
-			accessKeySearchAll:          "mgmt/accesskey/search",
-			accessKeyUpdate:             "mgmt/accesskey/update",
-			accessKeyDeactivate:         "mgmt/accesskey/deactivate",
-			accessKeyActivate:           "mgmt/accesskey/activate",
-			accessKeyDelete:             "mgmt/accesskey/delete",
-			ssoConfigure:                "mgmt/sso/settings",
-			ssoMetadata:                 "mgmt/sso/metadata",
-			ssoMapping:                  "mgmt/sso/mapping",
-			updateJWT:                   "mgmt/jwt/update",
-			permissionCreate:            "mgmt/permission/create",
-			permissionUpdate:            "mgmt/permission/update",
-			permissionDelete:            "mgmt/permission/delete",
-			permissionLoadAll:           "mgmt/permission/all",
-			roleCreate:                  "mgmt/role/create",
-			roleUpdate:                  "mgmt/role/update",
-			roleDelete:                  "mgmt/role/delete",
-			roleLoadAll:                 "mgmt/role/all",
-			groupLoadAllGroups:          "mgmt/group/all",
-			groupLoadAllGroupsForMember: "mgmt/group/member/all",
-			groupLoadAllGroupMembers:    "mgmt/group/members",
+			tenantCreate:                     "mgmt/tenant/create",
+			tenantUpdate:                     "mgmt/tenant/update",
+			tenantDelete:                     "mgmt/tenant/delete",
+			tenantLoadAll:                    "mgmt/tenant/all",
+			tenantLoad:                       "mgmt/tenant",
+			tenantSearchAll:                  "mgmt/tenant/search",
+			tenantSettings:                   "mgmt/tenant/settings",
+			userCreate:                       "mgmt/user/create",
+			userCreateTestUser:               "mgmt/user/create/test",
+			userUpdate:                       "mgmt/user/update",
+			userPatch:                        "mgmt/user/patch",
+			userDelete:                       "mgmt/user/delete",
+			userDeleteAllTestUsers:           "mgmt/user/test/delete/all",
+			userLoad:                         "mgmt/user",
+			userSearchAll:                    "mgmt/user/search",
+			userUpdateStatus:                 "mgmt/user/update/status",
+			userUpdateEmail:                  "mgmt/user/update/email",
+			userUpdatePhone:                  "mgmt/user/update/phone",
+			userUpdateName:                   "mgmt/user/update/name",
+			userUpdateCustomAttribute:        "mgmt/user/update/customAttribute",
+			userAddTenant:                    "mgmt/user/update/tenant/add",
+			userRemoveTenant:                 "mgmt/user/update/tenant/remove",
+			userAddRole:                      "mgmt/user/update/role/add",
+			userRemoveRole:                   "mgmt/user/update/role/remove",
+			userSetPassword:                  "mgmt/user/password/set",
+			userExpirePassword:               "mgmt/user/password/expire",
+			userGenerateOTPForTest:           "mgmt/tests/generate/otp",
+			userGenerateMagicLinkForTest:     "mgmt/tests/generate/magiclink",
+			userGenerateEnchantedLinkForTest: "mgmt/tests/generate/enchantedlink",
+			userImpersonate:                  "mgmt/impersonate",
+			accessKeyCreate:                  "mgmt/accesskey/create",
+			accessKeyLoad:                    "mgmt/accesskey",
+			accessKeySearchAll:               "mgmt/accesskey/search",
+			accessKeyUpdate:                  "mgmt/accesskey/update",
+			accessKeyDeactivate:              "mgmt/accesskey/deactivate",
+			accessKeyActivate:                "mgmt/accesskey/activate",
+			accessKeyDelete:                  "mgmt/accesskey/delete",
+			ssoConfigure:                     "mgmt/sso/settings",
+			ssoMetadata:                      "mgmt/sso/metadata",
+			ssoMapping:                       "mgmt/sso/mapping",
+			ssoOIDCConfigure:                 "mgmt/sso/oidc",
+			ssoLoadSettings:                  "mgmt/sso/settings",
+			ssoDeleteSettings:                "mgmt/sso/settings",
+			updateJWT:                        "mgmt/jwt/update",
+			permissionCreate:                 "mgmt/permission/create",
+			permissionUpdate:                 "mgmt/permission/update",
+			permissionDelete:                 "mgmt/permission/delete",
+			permissionLoadAll:                "mgmt/permission/all",
+			roleCreate:                       "mgmt/role/create",
+			roleUpdate:                       "mgmt/role/update",
+			roleDelete:                       "mgmt/role/delete",
+			roleLoadAll:                      "mgmt/role/all",
+			groupLoadAllGroups:               "mgmt/group/all",
+			groupLoadAllGroupsForMember:      "mgmt/group/member/all",
+			groupLoadAllGroupMembers:         "mgmt/group/members",
+			groupCreate:                      "mgmt/group/create",
+			groupUpdate:                      "mgmt/group/update",
+			groupDelete:                      "mgmt/group/delete",
+			groupAddMembers:                  "mgmt/group/members/add",
+			groupRemoveMembers:               "mgmt/group/members/remove",
+			auditSearch:                      "mgmt/audit/search",
+			flowList:                         "mgmt/flow/list",
+			flowExport:                       "mgmt/flow/export",
+			flowImport:                       "mgmt/flow/import",
+			themeExport:                      "mgmt/theme/export",
+			themeImport:                      "mgmt/theme/import",
 		},
 		logout:    "auth/logout",
 		logoutAll: "auth/logoutall",
@@ -133,6 +171,14 @@ type authEndpoints struct {
 	signUpTOTP                   string
 	updateTOTP                   string
 	verifyTOTPCode               string
+	signUpPassword               string
+	signInPassword               string
+	sendPasswordReset            string
+	updateUserPassword           string
+	replaceUserPassword          string
+	passwordPolicy               string
+	flowStart                    string
+	flowNext                     string
 	verifyCode                   string
 	signInMagicLink              string
 	signUpMagicLink              string
@@ -148,6 +194,9 @@ type authEndpoints struct {
 	exchangeTokenOAuth           string
 	samlStart                    string
 	exchangeTokenSAML            string
+	ssoStart                     string
+	exchangeTokenSSO             string
+	ssoTenant                    string
 	webauthnSignUpStart          string
 	webauthnSignUpFinish         string
 	webauthnSignInStart          string
@@ -163,24 +212,37 @@ type authEndpoints struct {
 }
 
 type mgmtEndpoints struct {
-	tenantCreate  string
-	tenantUpdate  string
-	tenantDelete  string
-	tenantLoadAll string
-
-	userCreate       string
-	userUpdate       string
-	userDelete       string
-	userLoad         string
-	userSearchAll    string
-	userUpdateStatus string
-	userUpdateEmail  string
-	userUpdatePhone  string
-	userUpdateName   string
-	userAddTenant    string
-	userRemoveTenant string
-	userAddRole      string
-	userRemoveRole   string
+	tenantCreate    string
+	tenantUpdate    string
+	tenantDelete    string
+	tenantLoadAll   string
+	tenantLoad      string
+	tenantSearchAll string
+	tenantSettings  string
+
+	userCreate                       string
+	userCreateTestUser               string
+	userUpdate                       string
+	userPatch                        string
+	userDelete                       string
+	userDeleteAllTestUsers           string
+	userLoad                         string
+	userSearchAll                    string
+	userUpdateStatus                 string
+	userUpdateEmail                  string
+	userUpdatePhone                  string
+	userUpdateName                   string
+	userUpdateCustomAttribute        string
+	userAddTenant                    string
+	userRemoveTenant                 string
+	userAddRole                      string
+	userRemoveRole                   string
+	userSetPassword                  string
+	userExpirePassword               string
+	userGenerateOTPForTest           string
+	userGenerateMagicLinkForTest     string
+	userGenerateEnchantedLinkForTest string
+	userImpersonate                  string
 
 	accessKeyCreate     string
 	accessKeyLoad       string
@@ -190,10 +252,13 @@ type mgmtEndpoints struct {
 	accessKeyActivate   string
 	accessKeyDelete     string
 
-	ssoConfigure string
-	ssoMetadata  string
-	ssoMapping   string
-	updateJWT    string
+	ssoConfigure      string
+	ssoMetadata       string
+	ssoMapping        string
+	ssoOIDCConfigure  string
+	ssoLoadSettings   string
+	ssoDeleteSettings string
+	updateJWT         string
 
 	permissionCreate  string
 	permissionUpdate  string
@@ -208,6 +273,17 @@ type mgmtEndpoints struct {
 	groupLoadAllGroups          string
 	groupLoadAllGroupsForMember string
 	groupLoadAllGroupMembers    string
+	groupCreate                 string
+	groupUpdate                 string
+	groupDelete                 string
+	groupAddMembers             string
+	groupRemoveMembers          string
+	auditSearch                 string
+	flowList                    string
+	flowExport                  string
+	flowImport                  string
+	themeExport                 string
+	themeImport                 string
 }
 
 func (e *endpoints) SignInOTP() string {
@@ -231,6 +307,30 @@ func (e *endpoints) VerifyCode() string {
 func (e *endpoints) VerifyTOTPCode() string {
 	return path.Join(e.version, e.auth.verifyTOTPCode)
 }
+func (e *endpoints) SignUpPassword() string {
+	return path.Join(e.version, e.auth.signUpPassword)
+}
+func (e *endpoints) SignInPassword() string {
+	return path.Join(e.version, e.auth.signInPassword)
+}
+func (e *endpoints) SendPasswordReset() string {
+	return path.Join(e.version, e.auth.sendPasswordReset)
+}
+func (e *endpoints) UpdateUserPassword() string {
+	return path.Join(e.version, e.auth.updateUserPassword)
+}
+func (e *endpoints) ReplaceUserPassword() string {
+	return path.Join(e.version, e.auth.replaceUserPassword)
+}
+func (e *endpoints) PasswordPolicy() string {
+	return path.Join(e.version, e.auth.passwordPolicy)
+}
+func (e *endpoints) FlowStart() string {
+	return path.Join(e.version, e.auth.flowStart)
+}
+func (e *endpoints) FlowNext() string {
+	return path.Join(e.version, e.auth.flowNext)
+}
 func (e *endpoints) SignInMagicLink() string {
 	return path.Join(e.version, e.auth.signInMagicLink)
 }
@@ -274,6 +374,15 @@ func (e *endpoints) SAMLStart() string {
 func (e *endpoints) ExchangeTokenSAML() string {
 	return path.Join(e.version, e.auth.exchangeTokenSAML)
 }
+func (e *endpoints) SSOStart() string {
+	return path.Join(e.version, e.auth.ssoStart)
+}
+func (e *endpoints) ExchangeTokenSSO() string {
+	return path.Join(e.version, e.auth.exchangeTokenSSO)
+}
+func (e *endpoints) SSOTenant() string {
+	return path.Join(e.version, e.auth.ssoTenant)
+}
 func (e *endpoints) WebAuthnSignUpStart() string {
 	return path.Join(e.version, e.auth.webauthnSignUpStart)
 }
@@ -347,18 +456,42 @@ func (e *endpoints) ManagementTenantLoadAll() string {
 	return path.Join(e.version, e.mgmt.tenantLoadAll)
 }
 
+func (e *endpoints) ManagementTenantLoad() string {
+	return path.Join(e.version, e.mgmt.tenantLoad)
+}
+
+func (e *endpoints) ManagementTenantSearchAll() string {
+	return path.Join(e.version, e.mgmt.tenantSearchAll)
+}
+
+func (e *endpoints) ManagementTenantSettings() string {
+	return path.Join(e.version, e.mgmt.tenantSettings)
+}
+
 func (e *endpoints) ManagementUserCreate() string {
 	return path.Join(e.version, e.mgmt.userCreate)
 }
 
+func (e *endpoints) ManagementUserCreateTestUser() string {
+	return path.Join(e.version, e.mgmt.userCreateTestUser)
+}
+
 func (e *endpoints) ManagementUserUpdate() string {
 	return path.Join(e.version, e.mgmt.userUpdate)
 }
 
+func (e *endpoints) ManagementUserPatch() string {
+	return path.Join(e.version, e.mgmt.userPatch)
+}
+
 func (e *endpoints) ManagementUserDelete() string {
 	return path.Join(e.version, e.mgmt.userDelete)
 }
 
+func (e *endpoints) ManagementUserDeleteAllTestUsers() string {
+	return path.Join(e.version, e.mgmt.userDeleteAllTestUsers)
+}
+
 func (e *endpoints) ManagementUserLoad() string {
 	return path.Join(e.version, e.mgmt.userLoad)
 }
@@ -383,6 +516,10 @@ func (e *endpoints) ManagementUserUpdateDisplayName() string {
 	return path.Join(e.version, e.mgmt.userUpdateName)
 }
 
+func (e *endpoints) ManagementUserUpdateCustomAttribute() string {
+	return path.Join(e.version, e.mgmt.userUpdateCustomAttribute)
+}
+
 func (e *endpoints) ManagementUserAddTenant() string {
 	return path.Join(e.version, e.mgmt.userAddTenant)
 }
@@ -399,6 +536,30 @@ func (e *endpoints) ManagementUserRemoveRole() string {
 	return path.Join(e.version, e.mgmt.userRemoveRole)
 }
 
+func (e *endpoints) ManagementUserSetPassword() string {
+	return path.Join(e.version, e.mgmt.userSetPassword)
+}
+
+func (e *endpoints) ManagementUserExpirePassword() string {
+	return path.Join(e.version, e.mgmt.userExpirePassword)
+}
+
+func (e *endpoints) ManagementGenerateOTPForTestUser() string {
+	return path.Join(e.version, e.mgmt.userGenerateOTPForTest)
+}
+
+func (e *endpoints) ManagementGenerateMagicLinkForTestUser() string {
+	return path.Join(e.version, e.mgmt.userGenerateMagicLinkForTest)
+}
+
+func (e *endpoints) ManagementGenerateEnchantedLinkForTestUser() string {
+	return path.Join(e.version, e.mgmt.userGenerateEnchantedLinkForTest)
+}
+
+func (e *endpoints) ManagementUserImpersonate() string {
+	return path.Join(e.version, e.mgmt.userImpersonate)
+}
+
 func (e *endpoints) ManagementAccessKeyCreate() string {
 	return path.Join(e.version, e.mgmt.accessKeyCreate)
 }
@@ -439,6 +600,18 @@ func (e *endpoints) ManagementSSOMapping() string {
 	return path.Join(e.version, e.mgmt.ssoMapping)
 }
 
+func (e *endpoints) ManagementSSOOIDCConfigure() string {
+	return path.Join(e.version, e.mgmt.ssoOIDCConfigure)
+}
+
+func (e *endpoints) ManagementSSOLoadSettings() string {
+	return path.Join(e.version, e.mgmt.ssoLoadSettings)
+}
+
+func (e *endpoints) ManagementSSODeleteSettings() string {
+	return path.Join(e.version, e.mgmt.ssoDeleteSettings)
+}
+
 func (e *endpoints) ManagementUpdateJWT() string {
 	return path.Join(e.version, e.mgmt.updateJWT)
 }
@@ -487,6 +660,50 @@ func (e *endpoints) ManagementGroupLoadAllGroupMembers() string {
 	return path.Join(e.version, e.mgmt.groupLoadAllGroupMembers)
 }
 
+func (e *endpoints) ManagementGroupCreate() string {
+	return path.Join(e.version, e.mgmt.groupCreate)
+}
+
+func (e *endpoints) ManagementGroupUpdate() string {
+	return path.Join(e.version, e.mgmt.groupUpdate)
+}
+
+func (e *endpoints) ManagementGroupDelete() string {
+	return path.Join(e.version, e.mgmt.groupDelete)
+}
+
+func (e *endpoints) ManagementGroupAddMembers() string {
+	return path.Join(e.version, e.mgmt.groupAddMembers)
+}
+
+func (e *endpoints) ManagementGroupRemoveMembers() string {
+	return path.Join(e.version, e.mgmt.groupRemoveMembers)
+}
+
+func (e *endpoints) ManagementAuditSearch() string {
+	return path.Join(e.version, e.mgmt.auditSearch)
+}
+
+func (e *endpoints) ManagementFlowList() string {
+	return path.Join(e.version, e.mgmt.flowList)
+}
+
+func (e *endpoints) ManagementFlowExport() string {
+	return path.Join(e.version, e.mgmt.flowExport)
+}
+
+func (e *endpoints) ManagementFlowImport() string {
+	return path.Join(e.version, e.mgmt.flowImport)
+}
+
+func (e *endpoints) ManagementThemeExport() string {
+	return path.Join(e.version, e.mgmt.themeExport)
+}
+
+func (e *endpoints) ManagementThemeImport() string {
+	return path.Join(e.version, e.mgmt.themeImport)
+}
+
 type sdkInfo struct {
 	name      string
 	version   string
@@ -566,6 +783,10 @@ func (c *Client) DoGetRequest(uri string, options *HTTPRequest, pswd string) (*H
 	return c.DoRequest(http.MethodGet, uri, nil, options, pswd)
 }
 
+func (c *Client) DoDeleteRequest(uri string, options *HTTPRequest, pswd string) (*HTTPResponse, error) {
+	return c.DoRequest(http.MethodDelete, uri, nil, options, pswd)
+}
+
 func (c *Client) DoPostRequest(uri string, body interface{}, options *HTTPRequest, pswd string) (*HTTPResponse, error) {
 	if options == nil {
 		options = &HTTPRequest{}
@@ -667,13 +888,13 @@ func (c *Client) DoRequest(method, uriPath string, body io.Reader, options *HTTP
 	resBytes, err := c.parseBody(response)
 	if err != nil { // notest
 		logger.LogError("Failed processing body from request to [%s]", err, url)
-		return nil, descope.ErrInvalidResponse
+		return nil, descope.ErrInvalidResponse.WithCause(err)
 	}
 
 	if options.ResBodyObj != nil {
 		if err = utils.Unmarshal(resBytes, &options.ResBodyObj); err != nil {
 			logger.LogError("Failed parsing body from request to [%s]", err, url)
-			return nil, descope.ErrInvalidResponse
+			return nil, descope.ErrInvalidResponse.WithCause(err)
 		}
 	}
 
@@ -698,11 +919,14 @@ func (c *Client) parseDescopeError(response *http.Response) *descope.Error {
 	body, err := c.parseBody(response)
 	if err != nil { // notest
 		logger.LogError("Failed to process error from server response", err)
-		return descope.ErrInvalidResponse
+		return descope.ErrInvalidResponse.WithCause(err)
 	}
 
 	var descopeErr *descope.Error
-	if err := json.Unmarshal(body, &descopeErr); err != nil || descopeErr.Code == "" {
+	if err := json.Unmarshal(body, &descopeErr); err != nil {
+		logger.LogError("Failed to parse error from server response", err)
+		return descope.ErrInvalidResponse.WithCause(err)
+	} else if descopeErr == nil || descopeErr.Code == "" {
 		logger.LogError("Failed to parse error from server response", err)
 		return descope.ErrInvalidResponse
 	}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -65,6 +68,19 @@ func TestGetRequest(t *testing.T) {
 	assert.EqualValues(t, expectedResponse, res.BodyStr)
 }
 
+func TestDeleteRequest(t *testing.T) {
+	projectID := "test"
+	c := NewClient(ClientParams{ProjectID: projectID, DefaultClient: mocks.NewTestClient(func(r *http.Request) (*http.Response, error) {
+		assert.Nil(t, r.Body)
+		assert.EqualValues(t, http.MethodDelete, r.Method)
+		assert.EqualValues(t, "/path", r.URL.Path)
+		assert.EqualValues(t, "test=1", r.URL.RawQuery)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})})
+	_, err := c.DoDeleteRequest("path", &HTTPRequest{QueryParams: map[string]string{"test": "1"}}, "")
+	require.NoError(t, err)
+}
+
 func TestPostRequest(t *testing.T) {
 	type dummy struct {
 		Test string
@@ -220,6 +236,30 @@ func TestPostUnknownError(t *testing.T) {
 	_, err := c.DoPostRequest("path", nil, nil, "")
 	require.Error(t, err)
 	assert.ErrorIs(t, err, descope.ErrInvalidResponse)
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+}
+
+func TestPostInvalidResponseBody(t *testing.T) {
+	c := NewClient(ClientParams{ProjectID: "test", DefaultClient: mocks.NewTestClient(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("not json"))}, nil
+	})})
+
+	res := map[string]any{}
+	_, err := c.DoPostRequest("path", nil, &HTTPRequest{ResBodyObj: &res}, "")
+	require.ErrorIs(t, err, descope.ErrInvalidResponse)
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+}
+
+func TestPostTimeoutError(t *testing.T) {
+	c := NewClient(ClientParams{ProjectID: "test", DefaultClient: mocks.NewTestClient(func(r *http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: "Post", URL: r.URL.String(), Err: context.DeadlineExceeded}
+	})})
+
+	_, err := c.DoPostRequest("path", nil, nil, "")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, descope.IsRetryable(err))
 }
 
 func TestPostNotFoundError(t *testing.T) {
@@ -0,0 +1,80 @@
+package descope
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/descope/go-sdk/descope/logger"
+)
+
+// Details about the signing certificate of an SSO identity provider
+type IdPCertificate struct {
+	Subject   string
+	Issuer    string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// IsExpired returns true if the certificate is no longer valid.
+func (c *IdPCertificate) IsExpired() bool {
+	return time.Now().After(c.NotAfter)
+}
+
+// ExpiresWithin returns true if the certificate is expired or will expire within
+// the given duration.
+func (c *IdPCertificate) ExpiresWithin(d time.Duration) bool {
+	return time.Now().Add(d).After(c.NotAfter)
+}
+
+// ParseIdPCertificate parses a PEM encoded certificate, or just its base64 encoded
+// contents as they appear in IdP metadata files, without checking its validity.
+func ParseIdPCertificate(cert string) (*IdPCertificate, error) {
+	cert = strings.TrimSpace(cert)
+	var der []byte
+	if block, _ := pem.Decode([]byte(cert)); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, ErrInvalidArguments.WithMessage("Unexpected PEM block type %s", block.Type)
+		}
+		der = block.Bytes
+	} else {
+		b, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(cert), ""))
+		if err != nil {
+			return nil, ErrInvalidArguments.WithMessage("Invalid certificate encoding").WithCause(err)
+		}
+		der = b
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, ErrInvalidArguments.WithMessage("Invalid certificate").WithCause(err)
+	}
+	return &IdPCertificate{
+		Subject:   c.Subject.String(),
+		Issuer:    c.Issuer.String(),
+		NotBefore: c.NotBefore,
+		NotAfter:  c.NotAfter,
+	}, nil
+}
+
+// ValidateIdPCertificate parses a certificate and checks that it's currently valid,
+// e.g., before passing it to the ConfigureSettings management function. If the
+// certificate expires within the warnBefore duration a warning is logged, and the
+// certificate is still returned without an error, so callers can alert about it.
+func ValidateIdPCertificate(cert string, warnBefore time.Duration) (*IdPCertificate, error) {
+	c, err := ParseIdPCertificate(cert)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsExpired() {
+		return c, ErrValidationFailure.WithMessage("The certificate expired at %s", c.NotAfter.Format(time.RFC3339))
+	}
+	if time.Now().Before(c.NotBefore) {
+		return c, ErrValidationFailure.WithMessage("The certificate isn't valid before %s", c.NotBefore.Format(time.RFC3339))
+	}
+	if warnBefore > 0 && c.ExpiresWithin(warnBefore) {
+		logger.LogInfo("The IdP certificate for [%s] expires soon at %s", c.Subject, c.NotAfter.Format(time.RFC3339))
+	}
+	return c, nil
+}
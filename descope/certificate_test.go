@@ -0,0 +1,81 @@
+package descope
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestCertificate(t *testing.T, notBefore, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.acme.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestParseIdPCertificate(t *testing.T) {
+	notAfter := time.Now().Add(365 * 24 * time.Hour).UTC().Truncate(time.Second)
+	cert := createTestCertificate(t, time.Now().Add(-time.Hour), notAfter)
+	c, err := ParseIdPCertificate(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "CN=idp.acme.com", c.Subject)
+	assert.Equal(t, notAfter, c.NotAfter)
+	assert.False(t, c.IsExpired())
+	assert.False(t, c.ExpiresWithin(24*time.Hour))
+	assert.True(t, c.ExpiresWithin(400*24*time.Hour))
+
+	// contents without the PEM header, as in metadata files
+	block, _ := pem.Decode([]byte(cert))
+	encoded := base64.StdEncoding.EncodeToString(block.Bytes)
+	c, err = ParseIdPCertificate("\n  " + encoded[:20] + "\n  " + encoded[20:] + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, notAfter, c.NotAfter)
+}
+
+func TestParseIdPCertificateInvalid(t *testing.T) {
+	_, err := ParseIdPCertificate("not a cert!")
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	_, err = ParseIdPCertificate("YWJj")
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	_, err = ParseIdPCertificate(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("abc")})))
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
+
+func TestValidateIdPCertificate(t *testing.T) {
+	cert := createTestCertificate(t, time.Now().Add(-time.Hour), time.Now().Add(10*24*time.Hour))
+	c, err := ValidateIdPCertificate(cert, 30*24*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, c.ExpiresWithin(30*24*time.Hour))
+
+	cert = createTestCertificate(t, time.Now().Add(-48*time.Hour), time.Now().Add(-24*time.Hour))
+	c, err = ValidateIdPCertificate(cert, 0)
+	assert.ErrorIs(t, err, ErrValidationFailure)
+	assert.True(t, strings.Contains(err.Error(), "expired"))
+	require.NotNil(t, c)
+	assert.True(t, c.IsExpired())
+
+	cert = createTestCertificate(t, time.Now().Add(24*time.Hour), time.Now().Add(48*time.Hour))
+	_, err = ValidateIdPCertificate(cert, 0)
+	assert.ErrorIs(t, err, ErrValidationFailure)
+
+	_, err = ValidateIdPCertificate("", 0)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
@@ -51,12 +51,17 @@ func NewWithConfig(config *Config) (*DescopeClient, error) {
 
 	c := api.NewClient(api.ClientParams{BaseURL: config.DescopeBaseURL, CustomDefaultHeaders: config.CustomDefaultHeaders, DefaultClient: config.DefaultClient, ProjectID: config.ProjectID})
 
-	authService, err := auth.NewAuth(auth.AuthParams{ProjectID: config.ProjectID, PublicKey: config.PublicKey, SessionJWTViaCookie: config.SessionJWTViaCookie, CookieDomain: config.SessionJWTCookieDomain}, c)
+	managementService := mgmt.NewManagement(mgmt.ManagementParams{ProjectID: config.ProjectID, ManagementKey: config.ManagementKey}, c)
+
+	authParams := auth.AuthParams{ProjectID: config.ProjectID, PublicKey: config.PublicKey, SessionJWTViaCookie: config.SessionJWTViaCookie, CookieDomain: config.SessionJWTCookieDomain, OAuthStateSecret: config.OAuthStateSecret, OAuthStateStore: config.OAuthStateStore}
+	if config.SSOTenantsCacheTTL > 0 && config.ManagementKey != "" {
+		authParams.TenantsLoader = managementService.Tenant().LoadAll
+		authParams.TenantsCacheTTL = config.SSOTenantsCacheTTL
+	}
+	authService, err := auth.NewAuth(authParams, c)
 	if err != nil {
 		return nil, err
 	}
 
-	managementService := mgmt.NewManagement(mgmt.ManagementParams{ProjectID: config.ProjectID, ManagementKey: config.ManagementKey}, c)
-
 	return &DescopeClient{Auth: authService, Management: managementService, config: config}, nil
 }
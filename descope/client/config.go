@@ -1,9 +1,12 @@
 package client
 
 import (
+	"time"
+
 	"github.com/descope/go-sdk/descope/api"
 	"github.com/descope/go-sdk/descope/internal/utils"
 	"github.com/descope/go-sdk/descope/logger"
+	"github.com/descope/go-sdk/descope/sdk"
 )
 
 // Conf - Configuration struct describes the configurational data for the authentication methods.
@@ -36,6 +39,17 @@ type Config struct {
 	SessionJWTViaCookie bool
 	// When using cookies, set the cookie domain here. Alternatively this can be done via the Descope console.
 	SessionJWTCookieDomain string
+	// SSOTenantsCacheTTL (optional, 0) - when set and a management key is provided, SSO().StartByEmail
+	// resolves tenants locally using an index of the tenants' self provisioning domains, which is loaded
+	// with Tenant().LoadAll() and reloaded after this duration. Otherwise tenants are resolved by the server.
+	SSOTenantsCacheTTL time.Duration
+	// OAuthStateSecret (optional, "") - when set, OAuth().StartWithOptions adds a signed state to the return URL
+	// and keeps it in a cookie, and OAuth().ExchangeTokenWithOptions verifies the state in the callback request.
+	OAuthStateSecret string
+	// OAuthStateStore (optional, nil) - when set, OAuth states are also saved in this store so each one can only
+	// be used once, even when the callback might be handled by a different host. The state is still bound to the
+	// user's browser with a cookie, unless the authentication is started with PKCE.
+	OAuthStateStore sdk.OAuthStateStore
 }
 
 func (c *Config) setProjectID() string {
@@ -0,0 +1,134 @@
+// Package config manages a project's authorization model as code. The desired
+// permissions, roles and tenants are described in a YAML or JSON file, compared
+// against the current state of the project, and the resulting plan is applied
+// using the management APIs.
+package config
+
+import (
+	"os"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// Project describes the desired authorization model of a project.
+//
+// A section that's missing entirely (nil) isn't managed, i.e., it's not loaded
+// from the project and no changes are planned for it. A section that's present
+// but empty means all existing entities of that type should be deleted.
+type Project struct {
+	Permissions []*descope.Permission `json:"permissions,omitempty"`
+	Roles       []*descope.Role       `json:"roles,omitempty"`
+	Tenants     []*descope.Tenant     `json:"tenants,omitempty"`
+}
+
+// Load reads and parses a project configuration file in either YAML or JSON format.
+func Load(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses a project configuration in either YAML or JSON format. The field
+// names are the same in both formats and match the JSON fields of the descope types.
+func Parse(data []byte) (*Project, error) {
+	// JSON is valid YAML, so the data is always read as YAML first and then
+	// converted to JSON so that the existing json tags are used for both formats
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, descope.ErrInvalidArguments.WithMessage("Failed to parse configuration").WithCause(err)
+	}
+	b, err := utils.Marshal(raw)
+	if err != nil {
+		return nil, descope.ErrInvalidArguments.WithMessage("Failed to parse configuration").WithCause(err)
+	}
+	project := &Project{}
+	if err := utils.Unmarshal(b, project); err != nil {
+		return nil, descope.ErrInvalidArguments.WithMessage("Failed to parse configuration").WithCause(err)
+	}
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// Validate ensures all entities have identifiers and that there are no duplicates.
+func (p *Project) Validate() error {
+	permissions := map[string]bool{}
+	for i, permission := range p.Permissions {
+		if permission == nil || permission.Name == "" {
+			return descope.ErrValidationFailure.WithMessage("Permission at index %d is missing a name", i)
+		}
+		if permissions[permission.Name] {
+			return descope.ErrValidationFailure.WithMessage("Duplicate permission %s", permission.Name)
+		}
+		permissions[permission.Name] = true
+	}
+	roles := map[string]bool{}
+	for i, role := range p.Roles {
+		if role == nil || role.Name == "" {
+			return descope.ErrValidationFailure.WithMessage("Role at index %d is missing a name", i)
+		}
+		if roles[role.Name] {
+			return descope.ErrValidationFailure.WithMessage("Duplicate role %s", role.Name)
+		}
+		roles[role.Name] = true
+		// only check references when the permissions are managed by this configuration
+		if p.Permissions != nil {
+			for _, name := range role.PermissionNames {
+				if !permissions[name] {
+					return descope.ErrValidationFailure.WithMessage("Role %s references unknown permission %s", role.Name, name)
+				}
+			}
+		}
+	}
+	tenants := map[string]bool{}
+	for i, tenant := range p.Tenants {
+		if tenant == nil || tenant.ID == "" {
+			return descope.ErrValidationFailure.WithMessage("Tenant at index %d is missing an id", i)
+		}
+		if tenant.Name == "" {
+			return descope.ErrValidationFailure.WithMessage("Tenant %s is missing a name", tenant.ID)
+		}
+		if tenants[tenant.ID] {
+			return descope.ErrValidationFailure.WithMessage("Duplicate tenant %s", tenant.ID)
+		}
+		tenants[tenant.ID] = true
+	}
+	return nil
+}
+
+// Current loads the current state of the project for every section that's
+// managed by the desired configuration.
+func Current(mgmt sdk.Management, desired *Project) (*Project, error) {
+	if desired == nil {
+		return nil, utils.NewInvalidArgumentError("desired")
+	}
+	current := &Project{}
+	if desired.Permissions != nil {
+		permissions, err := mgmt.Permission().LoadAll()
+		if err != nil {
+			return nil, err
+		}
+		current.Permissions = append([]*descope.Permission{}, permissions...)
+	}
+	if desired.Roles != nil {
+		roles, err := mgmt.Role().LoadAll()
+		if err != nil {
+			return nil, err
+		}
+		current.Roles = append([]*descope.Role{}, roles...)
+	}
+	if desired.Tenants != nil {
+		tenants, err := mgmt.Tenant().LoadAll()
+		if err != nil {
+			return nil, err
+		}
+		current.Tenants = append([]*descope.Tenant{}, tenants...)
+	}
+	return current, nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	mocksmgmt "github.com/descope/go-sdk/descope/tests/mocks/mgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlConfig = `
+permissions:
+  - name: read
+    description: Read access
+  - name: write
+roles:
+  - name: viewer
+    permissionNames: [read]
+  - name: editor
+    description: Can edit
+    permissionNames: [read, write]
+`
+
+func TestParseYAML(t *testing.T) {
+	p, err := Parse([]byte(yamlConfig))
+	require.NoError(t, err)
+	require.Len(t, p.Permissions, 2)
+	assert.Equal(t, "read", p.Permissions[0].Name)
+	assert.Equal(t, "Read access", p.Permissions[0].Description)
+	require.Len(t, p.Roles, 2)
+	assert.Equal(t, "editor", p.Roles[1].Name)
+	assert.EqualValues(t, []string{"read", "write"}, p.Roles[1].PermissionNames)
+	assert.Nil(t, p.Tenants)
+}
+
+func TestParseJSON(t *testing.T) {
+	p, err := Parse([]byte(`{"tenants":[{"id":"t1","name":"Tenant","selfProvisioningDomains":["a.com"]}],"permissions":[]}`))
+	require.NoError(t, err)
+	require.Len(t, p.Tenants, 1)
+	assert.Equal(t, "t1", p.Tenants[0].ID)
+	assert.EqualValues(t, []string{"a.com"}, p.Tenants[0].SelfProvisioningDomains)
+	assert.NotNil(t, p.Permissions)
+	assert.Empty(t, p.Permissions)
+	assert.Nil(t, p.Roles)
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse([]byte("permissions: [\n"))
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	_, err = Parse([]byte("permissions: foo"))
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestValidate(t *testing.T) {
+	for _, data := range []string{
+		"permissions: [{description: foo}]",
+		"permissions: [{name: a}, {name: a}]",
+		"roles: [{name: a}, {name: a}]",
+		"roles: [{description: a}]",
+		"permissions: [{name: a}]\nroles: [{name: r, permissionNames: [b]}]",
+		"tenants: [{name: a}]",
+		"tenants: [{id: a}]",
+		"tenants: [{id: a, name: a}, {id: a, name: b}]",
+	} {
+		_, err := Parse([]byte(data))
+		assert.ErrorIs(t, err, descope.ErrValidationFailure, data)
+	}
+
+	// role permissions aren't checked when permissions aren't managed
+	_, err := Parse([]byte("roles: [{name: r, permissionNames: [b]}]"))
+	assert.NoError(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yamlConfig), 0600))
+	p, err := Load(path)
+	require.NoError(t, err)
+	assert.Len(t, p.Roles, 2)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestCurrent(t *testing.T) {
+	mgmt := &mocksmgmt.MockManagement{
+		MockPermission: &mocksmgmt.MockPermission{LoadAllResponse: []*descope.Permission{{Name: "read"}}},
+		MockRole:       &mocksmgmt.MockRole{LoadAllError: descope.ErrRateLimitExceeded},
+		MockTenant:     &mocksmgmt.MockTenant{LoadAllError: descope.ErrRateLimitExceeded},
+	}
+	current, err := Current(mgmt, &Project{Permissions: []*descope.Permission{}})
+	require.NoError(t, err)
+	assert.Len(t, current.Permissions, 1)
+	assert.Nil(t, current.Roles)
+	assert.Nil(t, current.Tenants)
+
+	_, err = Current(mgmt, &Project{Roles: []*descope.Role{}})
+	assert.ErrorIs(t, err, descope.ErrRateLimitExceeded)
+
+	_, err = Current(mgmt, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
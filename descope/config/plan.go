@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/logger"
+	"github.com/descope/go-sdk/descope/sdk"
+	"golang.org/x/exp/slices"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type ResourceType string
+
+const (
+	ResourcePermission ResourceType = "permission"
+	ResourceRole       ResourceType = "role"
+	ResourceTenant     ResourceType = "tenant"
+)
+
+// Change is a single operation in a plan. Exactly one of the Permission, Role or
+// Tenant fields is set according to the resource type, and holds the desired value
+// for create and update actions or the current value for delete actions.
+type Change struct {
+	Action     Action
+	Resource   ResourceType
+	Permission *descope.Permission
+	Role       *descope.Role
+	Tenant     *descope.Tenant
+}
+
+// The name of the permission or role, or the ID of the tenant this change applies to.
+func (c *Change) Key() string {
+	switch c.Resource {
+	case ResourcePermission:
+		return c.Permission.Name
+	case ResourceRole:
+		return c.Role.Name
+	case ResourceTenant:
+		return c.Tenant.ID
+	}
+	return ""
+}
+
+func (c *Change) String() string {
+	symbols := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}
+	return fmt.Sprintf("%s %s %s", symbols[c.Action], c.Resource, c.Key())
+}
+
+// Plan is the list of changes required to bring the project to the desired state,
+// ordered such that they can be applied one after the other, e.g., permissions are
+// created before the roles that reference them, and deleted after them.
+type Plan struct {
+	Changes []*Change
+}
+
+func (p *Plan) IsEmpty() bool {
+	return p == nil || len(p.Changes) == 0
+}
+
+func (p *Plan) String() string {
+	if p.IsEmpty() {
+		return "No changes"
+	}
+	lines := []string{}
+	for _, change := range p.Changes {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NewPlan loads the current state of the project and returns the changes
+// required to bring it to the desired state.
+func NewPlan(mgmt sdk.Management, desired *Project) (*Plan, error) {
+	current, err := Current(mgmt, desired)
+	if err != nil {
+		return nil, err
+	}
+	return Diff(current, desired), nil
+}
+
+// Diff returns the changes required to bring the current project state to the
+// desired one. Sections that aren't managed by the desired configuration are ignored.
+func Diff(current, desired *Project) *Plan {
+	if current == nil {
+		current = &Project{}
+	}
+	if desired == nil {
+		desired = &Project{}
+	}
+
+	var upserts, deletes []*Change
+
+	if desired.Permissions != nil {
+		existing := map[string]*descope.Permission{}
+		for _, permission := range current.Permissions {
+			existing[permission.Name] = permission
+		}
+		for _, permission := range desired.Permissions {
+			if old, ok := existing[permission.Name]; !ok {
+				upserts = append(upserts, &Change{Action: ActionCreate, Resource: ResourcePermission, Permission: permission})
+			} else if old.Description != permission.Description {
+				upserts = append(upserts, &Change{Action: ActionUpdate, Resource: ResourcePermission, Permission: permission})
+			}
+			delete(existing, permission.Name)
+		}
+		for _, permission := range current.Permissions {
+			if _, ok := existing[permission.Name]; ok {
+				deletes = append(deletes, &Change{Action: ActionDelete, Resource: ResourcePermission, Permission: permission})
+			}
+		}
+	}
+
+	if desired.Roles != nil {
+		existing := map[string]*descope.Role{}
+		for _, role := range current.Roles {
+			existing[role.Name] = role
+		}
+		for _, role := range desired.Roles {
+			if old, ok := existing[role.Name]; !ok {
+				upserts = append(upserts, &Change{Action: ActionCreate, Resource: ResourceRole, Role: role})
+			} else if old.Description != role.Description || !sameElements(old.PermissionNames, role.PermissionNames) {
+				upserts = append(upserts, &Change{Action: ActionUpdate, Resource: ResourceRole, Role: role})
+			}
+			delete(existing, role.Name)
+		}
+		// roles are deleted before the permissions they might reference
+		var roleDeletes []*Change
+		for _, role := range current.Roles {
+			if _, ok := existing[role.Name]; ok {
+				roleDeletes = append(roleDeletes, &Change{Action: ActionDelete, Resource: ResourceRole, Role: role})
+			}
+		}
+		deletes = append(roleDeletes, deletes...)
+	}
+
+	if desired.Tenants != nil {
+		existing := map[string]*descope.Tenant{}
+		for _, tenant := range current.Tenants {
+			existing[tenant.ID] = tenant
+		}
+		for _, tenant := range desired.Tenants {
+			if old, ok := existing[tenant.ID]; !ok {
+				upserts = append(upserts, &Change{Action: ActionCreate, Resource: ResourceTenant, Tenant: tenant})
+			} else if old.Name != tenant.Name || !sameElements(old.SelfProvisioningDomains, tenant.SelfProvisioningDomains) {
+				upserts = append(upserts, &Change{Action: ActionUpdate, Resource: ResourceTenant, Tenant: tenant})
+			}
+			delete(existing, tenant.ID)
+		}
+		for _, tenant := range current.Tenants {
+			if _, ok := existing[tenant.ID]; ok {
+				deletes = append(deletes, &Change{Action: ActionDelete, Resource: ResourceTenant, Tenant: tenant})
+			}
+		}
+	}
+
+	return &Plan{Changes: append(upserts, deletes...)}
+}
+
+type ApplyOptions struct {
+	// When set no changes are made to the project, and the returned list of
+	// changes is the one that would have been applied.
+	DryRun bool
+	// An optional callback that's called before each change is applied.
+	OnChange func(change *Change)
+}
+
+// Apply performs the changes in the plan in order. It stops at the first failure
+// and returns the changes that were successfully applied until that point.
+func Apply(mgmt sdk.Management, plan *Plan, options *ApplyOptions) ([]*Change, error) {
+	if plan == nil {
+		return nil, utils.NewInvalidArgumentError("plan")
+	}
+	if options == nil {
+		options = &ApplyOptions{}
+	}
+	applied := []*Change{}
+	for _, change := range plan.Changes {
+		if options.OnChange != nil {
+			options.OnChange(change)
+		}
+		if !options.DryRun {
+			if err := applyChange(mgmt, change); err != nil {
+				logger.LogError("Failed to apply change [%s]", err, change)
+				return applied, err
+			}
+		}
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
+
+func applyChange(mgmt sdk.Management, change *Change) error {
+	switch change.Resource {
+	case ResourcePermission:
+		p := change.Permission
+		switch change.Action {
+		case ActionCreate:
+			return mgmt.Permission().Create(p.Name, p.Description)
+		case ActionUpdate:
+			return mgmt.Permission().Update(p.Name, p.Name, p.Description)
+		case ActionDelete:
+			return mgmt.Permission().Delete(p.Name)
+		}
+	case ResourceRole:
+		r := change.Role
+		switch change.Action {
+		case ActionCreate:
+			return mgmt.Role().Create(r.Name, r.Description, r.PermissionNames)
+		case ActionUpdate:
+			return mgmt.Role().Update(r.Name, r.Name, r.Description, r.PermissionNames)
+		case ActionDelete:
+			return mgmt.Role().Delete(r.Name)
+		}
+	case ResourceTenant:
+		t := change.Tenant
+		switch change.Action {
+		case ActionCreate:
+			return mgmt.Tenant().CreateWithID(t.ID, t.Name, t.SelfProvisioningDomains)
+		case ActionUpdate:
+			return mgmt.Tenant().Update(t.ID, t.Name, t.SelfProvisioningDomains)
+		case ActionDelete:
+			return mgmt.Tenant().Delete(t.ID)
+		}
+	}
+	return descope.ErrInvalidArguments.WithMessage("Unsupported change [%s]", change)
+}
+
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	mocksmgmt "github.com/descope/go-sdk/descope/tests/mocks/mgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	current := &Project{
+		Permissions: []*descope.Permission{{Name: "read"}, {Name: "write", Description: "old"}, {Name: "admin"}},
+		Roles:       []*descope.Role{{Name: "viewer", PermissionNames: []string{"read"}}, {Name: "root", PermissionNames: []string{"admin"}}},
+		Tenants:     []*descope.Tenant{{ID: "t1", Name: "One", SelfProvisioningDomains: []string{"a.com", "b.com"}}, {ID: "t2", Name: "Two"}},
+	}
+	desired := &Project{
+		Permissions: []*descope.Permission{{Name: "read"}, {Name: "write", Description: "new"}, {Name: "delete"}},
+		Roles:       []*descope.Role{{Name: "viewer", PermissionNames: []string{"read"}}, {Name: "editor", PermissionNames: []string{"write", "delete"}}},
+		Tenants:     []*descope.Tenant{{ID: "t1", Name: "One", SelfProvisioningDomains: []string{"b.com", "a.com"}}, {ID: "t3", Name: "Three"}},
+	}
+	plan := Diff(current, desired)
+	require.False(t, plan.IsEmpty())
+	assert.Equal(t, `~ permission write
++ permission delete
++ role editor
++ tenant t3
+- role root
+- permission admin
+- tenant t2`, plan.String())
+	assert.Equal(t, "new", plan.Changes[0].Permission.Description)
+	assert.Equal(t, "Two", plan.Changes[6].Tenant.Name)
+}
+
+func TestDiffUnmanaged(t *testing.T) {
+	current := &Project{
+		Permissions: []*descope.Permission{{Name: "read"}},
+		Roles:       []*descope.Role{{Name: "viewer"}},
+	}
+	plan := Diff(current, &Project{Roles: []*descope.Role{}})
+	assert.Equal(t, "- role viewer", plan.String())
+
+	plan = Diff(current, &Project{})
+	assert.True(t, plan.IsEmpty())
+	assert.Equal(t, "No changes", plan.String())
+}
+
+func TestNewPlan(t *testing.T) {
+	mgmt := &mocksmgmt.MockManagement{
+		MockRole: &mocksmgmt.MockRole{LoadAllResponse: []*descope.Role{{Name: "viewer", Description: "a"}}},
+	}
+	plan, err := NewPlan(mgmt, &Project{Roles: []*descope.Role{{Name: "viewer", Description: "b"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "~ role viewer", plan.String())
+
+	mgmt.MockRole.LoadAllError = descope.ErrRateLimitExceeded
+	_, err = NewPlan(mgmt, &Project{Roles: []*descope.Role{}})
+	assert.ErrorIs(t, err, descope.ErrRateLimitExceeded)
+}
+
+func TestApply(t *testing.T) {
+	calls := []string{}
+	mgmt := &mocksmgmt.MockManagement{
+		MockPermission: &mocksmgmt.MockPermission{
+			CreateAssert: func(name, description string) { calls = append(calls, "create permission "+name) },
+			DeleteAssert: func(name string) { calls = append(calls, "delete permission "+name) },
+		},
+		MockRole: &mocksmgmt.MockRole{
+			UpdateAssert: func(name, newName, description string, permissionNames []string) {
+				assert.Equal(t, name, newName)
+				assert.EqualValues(t, []string{"p1"}, permissionNames)
+				calls = append(calls, "update role "+name)
+			},
+		},
+		MockTenant: &mocksmgmt.MockTenant{
+			CreateWithIDAssert: func(id, name string, selfProvisioningDomains []string) {
+				calls = append(calls, "create tenant "+id)
+			},
+		},
+	}
+	plan := &Plan{Changes: []*Change{
+		{Action: ActionCreate, Resource: ResourcePermission, Permission: &descope.Permission{Name: "p1"}},
+		{Action: ActionUpdate, Resource: ResourceRole, Role: &descope.Role{Name: "r1", PermissionNames: []string{"p1"}}},
+		{Action: ActionCreate, Resource: ResourceTenant, Tenant: &descope.Tenant{ID: "t1", Name: "T1"}},
+		{Action: ActionDelete, Resource: ResourcePermission, Permission: &descope.Permission{Name: "p2"}},
+	}}
+	applied, err := Apply(mgmt, plan, nil)
+	require.NoError(t, err)
+	assert.Len(t, applied, 4)
+	assert.EqualValues(t, []string{"create permission p1", "update role r1", "create tenant t1", "delete permission p2"}, calls)
+}
+
+func TestApplyDryRun(t *testing.T) {
+	mgmt := &mocksmgmt.MockManagement{
+		MockPermission: &mocksmgmt.MockPermission{
+			CreateAssert: func(name, description string) { assert.Fail(t, "should not be called") },
+		},
+	}
+	plan := &Plan{Changes: []*Change{
+		{Action: ActionCreate, Resource: ResourcePermission, Permission: &descope.Permission{Name: "p1"}},
+	}}
+	seen := 0
+	applied, err := Apply(mgmt, plan, &ApplyOptions{DryRun: true, OnChange: func(change *Change) { seen++ }})
+	require.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, 1, seen)
+}
+
+func TestApplyFailure(t *testing.T) {
+	mgmt := &mocksmgmt.MockManagement{
+		MockPermission: &mocksmgmt.MockPermission{},
+		MockRole:       &mocksmgmt.MockRole{CreateError: descope.ErrBadRequest},
+	}
+	plan := &Plan{Changes: []*Change{
+		{Action: ActionCreate, Resource: ResourcePermission, Permission: &descope.Permission{Name: "p1"}},
+		{Action: ActionCreate, Resource: ResourceRole, Role: &descope.Role{Name: "r1"}},
+		{Action: ActionDelete, Resource: ResourcePermission, Permission: &descope.Permission{Name: "p2"}},
+	}}
+	applied, err := Apply(mgmt, plan, nil)
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "p1", applied[0].Key())
+
+	_, err = Apply(mgmt, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
@@ -0,0 +1,126 @@
+package descope
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrorCategory groups error codes by the area of the service that produced them
+type ErrorCategory string
+
+const (
+	ErrorCategoryUnknown        ErrorCategory = ""
+	ErrorCategoryValidation     ErrorCategory = "validation"
+	ErrorCategoryAuthentication ErrorCategory = "authentication"
+	ErrorCategoryManagement     ErrorCategory = "management"
+	ErrorCategoryRateLimit      ErrorCategory = "ratelimit"
+	ErrorCategoryInfra          ErrorCategory = "infra"
+	ErrorCategoryClient         ErrorCategory = "client"
+)
+
+// ErrorCodeInfo describes a known error code, the category it belongs to and the
+// HTTP status code the server usually responds with alongside it
+type ErrorCodeInfo struct {
+	Code        string
+	Category    ErrorCategory
+	HTTPStatus  int
+	Description string
+}
+
+// The table of known error codes in errorcodes_table.go is generated from errorcodes.csv,
+// which lists the codes the SDK defines in errors.go. It's not a complete list of the codes
+// the server can return, and codes that aren't listed are still categorized according to
+// their prefix, see LookupErrorCode.
+//
+//go:generate go run errorcodes_gen.go
+
+// LookupErrorCode returns information about an error code. Codes that aren't in the
+// table of known codes get their category from the code prefix, and a zero HTTP status.
+func LookupErrorCode(code string) *ErrorCodeInfo {
+	if info, ok := errorCodes[code]; ok {
+		return info
+	}
+	return &ErrorCodeInfo{Code: code, Category: categoryFromPrefix(code)}
+}
+
+func categoryFromPrefix(code string) ErrorCategory {
+	switch {
+	case strings.HasPrefix(code, "G"):
+		return ErrorCategoryClient
+	case strings.HasPrefix(code, "E01"):
+		return ErrorCategoryValidation
+	case strings.HasPrefix(code, "E06"):
+		return ErrorCategoryAuthentication
+	case strings.HasPrefix(code, "E11"):
+		return ErrorCategoryManagement
+	case strings.HasPrefix(code, "E13"):
+		return ErrorCategoryInfra
+	}
+	return ErrorCategoryUnknown
+}
+
+// Category returns the category of the error according to its code
+func (e *Error) Category() ErrorCategory {
+	if e == nil {
+		return ErrorCategoryUnknown
+	}
+	return LookupErrorCode(e.Code).Category
+}
+
+// HTTPStatus returns the status code of the server response that caused the error,
+// or the status code usually associated with the error code if it's not available.
+func (e *Error) HTTPStatus() int {
+	if e == nil {
+		return 0
+	}
+	if status, ok := e.Info[ErrorInfoKeys.HTTPResponseStatusCode].(int); ok {
+		return status
+	}
+	return LookupErrorCode(e.Code).HTTPStatus
+}
+
+// IsRateLimited returns true if the error, or any error it wraps, was caused by
+// exceeding the API rate limits.
+func IsRateLimited(err error) bool {
+	var de *Error
+	if !errors.As(err, &de) || de == nil {
+		return false
+	}
+	return de.Is(ErrRateLimitExceeded) || de.HTTPStatus() == http.StatusTooManyRequests
+}
+
+// RetryAfter returns how long to wait before retrying a rate limited request,
+// or 0 if the error doesn't carry that information.
+func RetryAfter(err error) time.Duration {
+	var de *Error
+	if !errors.As(err, &de) || de == nil {
+		return 0
+	}
+	if seconds, ok := de.Info[ErrorInfoKeys.RateLimitExceededRetryAfter].(int); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+// IsRetryable returns true if the failed operation might succeed when tried again
+// later, e.g., after a rate limit, a temporary server error or a network timeout.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsRateLimited(err) {
+		return true
+	}
+	var de *Error
+	if errors.As(err, &de) && de != nil {
+		switch de.HTTPStatus() {
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,69 @@
+//go:build ignore
+
+// Generates errorcodes_table.go from errorcodes.csv, run with go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+)
+
+var categories = map[string]string{
+	"validation":     "ErrorCategoryValidation",
+	"authentication": "ErrorCategoryAuthentication",
+	"management":     "ErrorCategoryManagement",
+	"ratelimit":      "ErrorCategoryRateLimit",
+	"infra":          "ErrorCategoryInfra",
+	"client":         "ErrorCategoryClient",
+}
+
+func main() {
+	f, err := os.Open("errorcodes.csv")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "// Code generated by errorcodes_gen.go from errorcodes.csv; DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package descope")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "var errorCodes = map[string]*ErrorCodeInfo{")
+	seen := map[string]bool{}
+	for i, record := range records[1:] {
+		if len(record) != 4 {
+			log.Fatalf("line %d: expected 4 fields, got %d", i+2, len(record))
+		}
+		code, category, status, description := record[0], categories[record[1]], record[2], record[3]
+		if category == "" {
+			log.Fatalf("line %d: unknown category %q", i+2, record[1])
+		}
+		if _, err := strconv.Atoi(status); err != nil {
+			log.Fatalf("line %d: invalid HTTP status %q", i+2, status)
+		}
+		if seen[code] {
+			log.Fatalf("line %d: duplicate code %s", i+2, code)
+		}
+		seen[code] = true
+		fmt.Fprintf(buf, "%q: {Code: %q, Category: %s, HTTPStatus: %s, Description: %q},\n", code, code, category, status, description)
+	}
+	fmt.Fprintln(buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("errorcodes_table.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,25 @@
+// Code generated by errorcodes_gen.go from errorcodes.csv; DO NOT EDIT.
+
+package descope
+
+var errorCodes = map[string]*ErrorCodeInfo{
+	"E011001": {Code: "E011001", Category: ErrorCategoryValidation, HTTPStatus: 400, Description: "Request is malformed"},
+	"E011002": {Code: "E011002", Category: ErrorCategoryValidation, HTTPStatus: 400, Description: "Invalid arguments"},
+	"E011003": {Code: "E011003", Category: ErrorCategoryValidation, HTTPStatus: 400, Description: "Validation failure"},
+	"E011004": {Code: "E011004", Category: ErrorCategoryValidation, HTTPStatus: 400, Description: "Missing arguments"},
+	"E061102": {Code: "E061102", Category: ErrorCategoryAuthentication, HTTPStatus: 401, Description: "Invalid one time code"},
+	"E062107": {Code: "E062107", Category: ErrorCategoryAuthentication, HTTPStatus: 400, Description: "User already exists"},
+	"E062503": {Code: "E062503", Category: ErrorCategoryAuthentication, HTTPStatus: 401, Description: "Enchanted link is pending or unauthorized"},
+	"E112102": {Code: "E112102", Category: ErrorCategoryManagement, HTTPStatus: 404, Description: "User not found"},
+	"E130429": {Code: "E130429", Category: ErrorCategoryRateLimit, HTTPStatus: 429, Description: "Rate limit exceeded"},
+	"G010001": {Code: "G010001", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Missing project ID"},
+	"G020001": {Code: "G020001", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Unexpected server response"},
+	"G020002": {Code: "G020002", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Invalid server response"},
+	"G030001": {Code: "G030001", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Missing or invalid public key"},
+	"G030002": {Code: "G030002", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Invalid token"},
+	"G030003": {Code: "G030003", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Missing or invalid refresh token"},
+	"G030004": {Code: "G030004", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Refresh token must be provided for stepup actions"},
+	"G040001": {Code: "G040001", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "No SSO tenant matches the email domain"},
+	"G040002": {Code: "G040002", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Multiple SSO tenants match the email domain"},
+	"G050001": {Code: "G050001", Category: ErrorCategoryClient, HTTPStatus: 0, Description: "Missing or invalid OAuth state"},
+}
@@ -1,6 +1,7 @@
 package descope
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -36,6 +37,13 @@ var (
 	ErrInvalidToken     = newClientError("G030002", "Invalid token")
 	ErrRefreshToken     = newClientError("G030003", "Missing or invalid refresh token")
 	ErrInvalidStepUpJWT = newClientError("G030004", "Refresh token must be provided for stepup actions")
+
+	// client sso errors
+	ErrSSOTenantNotFound  = newClientError("G040001", "No SSO tenant matches the email domain")
+	ErrSSOMultipleTenants = newClientError("G040002", "Multiple SSO tenants match the email domain")
+
+	// client oauth errors
+	ErrInvalidOAuthState = newClientError("G050001", "Missing or invalid OAuth state")
 )
 
 // Additional information that might be available in the
@@ -50,14 +58,21 @@ type Error struct {
 	Description string         `json:"errorDescription,omitempty"`
 	Message     string         `json:"errorMessage,omitempty"`
 	Info        map[string]any `json:"-"`
+	// The underlying error that caused this error, if any. It's exposed via Unwrap,
+	// so errors.Is and errors.As can be used to inspect it.
+	Cause error `json:"-"`
 }
 
 func (e *Error) Error() string {
 	str := fmt.Sprintf("[%s]", e.Code)
-	if e.Description != "" && e.Message != "" {
-		str = fmt.Sprintf("%s %s: %s", str, e.Description, e.Message)
-	} else if e.Description != "" || e.Message != "" {
-		str = fmt.Sprintf("%s %s%s", str, e.Description, e.Message)
+	message := e.Message
+	if message == "" && e.Cause != nil {
+		message = e.Cause.Error()
+	}
+	if e.Description != "" && message != "" {
+		str = fmt.Sprintf("%s %s: %s", str, e.Description, message)
+	} else if e.Description != "" || message != "" {
+		str = fmt.Sprintf("%s %s%s", str, e.Description, message)
 	}
 	if len(e.Info) > 0 {
 		str = fmt.Sprintf("%s %s", str, strings.TrimPrefix(fmt.Sprintf("%v", e.Info), "map"))
@@ -77,6 +92,18 @@ func (e Error) WithMessage(format string, args ...any) *Error {
 	return &e
 }
 
+func (e Error) WithCause(err error) *Error {
+	e.Cause = err
+	return &e
+}
+
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Cause
+}
+
 func (e Error) WithInfo(key string, value any) *Error {
 	if e.Info == nil {
 		e.Info = map[string]any{}
@@ -94,14 +121,16 @@ func (e *Error) IsNotFound() bool {
 }
 
 func IsUnauthorizedError(err error) bool {
-	if e, ok := err.(*Error); ok {
+	var e *Error
+	if errors.As(err, &e) {
 		return e.IsUnauthorized()
 	}
 	return false
 }
 
 func IsNotFoundError(err error) bool {
-	if e, ok := err.(*Error); ok {
+	var e *Error
+	if errors.As(err, &e) {
 		return e.IsNotFound()
 	}
 	return false
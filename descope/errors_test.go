@@ -1,8 +1,12 @@
 package descope
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -93,3 +97,108 @@ func TestStatusCode(t *testing.T) {
 	require.False(t, IsUnauthorizedError(assert.AnError))
 	require.False(t, IsNotFoundError(assert.AnError))
 }
+
+func TestStatusCodeWrapped(t *testing.T) {
+	unauth := newServerError("E123").WithInfo(ErrorInfoKeys.HTTPResponseStatusCode, 401)
+	notfound := newServerError("E234").WithInfo(ErrorInfoKeys.HTTPResponseStatusCode, 404)
+	require.True(t, IsUnauthorizedError(fmt.Errorf("wrapped: %w", unauth)))
+	require.True(t, IsNotFoundError(fmt.Errorf("wrapped: %w", notfound)))
+	require.False(t, IsNotFoundError(fmt.Errorf("wrapped: %w", unauth)))
+}
+
+func TestErrorCategory(t *testing.T) {
+	require.Equal(t, ErrorCategoryValidation, ErrBadRequest.Category())
+	require.Equal(t, ErrorCategoryAuthentication, ErrInvalidOneTimeCode.Category())
+	require.Equal(t, ErrorCategoryManagement, ErrManagementUserNotFound.Category())
+	require.Equal(t, ErrorCategoryRateLimit, ErrRateLimitExceeded.Category())
+	require.Equal(t, ErrorCategoryClient, ErrInvalidToken.Category())
+
+	// unlisted codes fall back to their prefix
+	require.Equal(t, ErrorCategoryAuthentication, newServerError("E069999").Category())
+	require.Equal(t, ErrorCategoryManagement, newServerError("E119999").Category())
+	require.Equal(t, ErrorCategoryInfra, newServerError("E139999").Category())
+	require.Equal(t, ErrorCategoryUnknown, newServerError("foo").Category())
+
+	var de *Error
+	require.Equal(t, ErrorCategoryUnknown, de.Category())
+}
+
+func TestErrorCodesTable(t *testing.T) {
+	for _, err := range []*Error{
+		ErrBadRequest, ErrInvalidArguments, ErrValidationFailure, ErrMissingArguments,
+		ErrUserAlreadyExists, ErrInvalidOneTimeCode, ErrEnchantedLinkUnauthorized,
+		ErrManagementUserNotFound, ErrRateLimitExceeded, ErrMissingProjectID,
+		ErrUnexpectedResponse, ErrInvalidResponse, ErrPublicKey, ErrInvalidToken,
+		ErrRefreshToken, ErrInvalidStepUpJWT, ErrSSOTenantNotFound, ErrSSOMultipleTenants,
+		ErrInvalidOAuthState,
+	} {
+		info, ok := errorCodes[err.Code]
+		require.True(t, ok, err.Code)
+		if err.Description != "" {
+			require.Equal(t, err.Description, info.Description, err.Code)
+		}
+	}
+	require.Equal(t, ErrorCategoryClient, ErrInvalidOAuthState.Category())
+}
+
+func TestErrorHTTPStatus(t *testing.T) {
+	require.Equal(t, http.StatusBadRequest, ErrBadRequest.HTTPStatus())
+	require.Equal(t, http.StatusTooManyRequests, ErrRateLimitExceeded.HTTPStatus())
+	require.Equal(t, http.StatusConflict, ErrBadRequest.WithInfo(ErrorInfoKeys.HTTPResponseStatusCode, http.StatusConflict).HTTPStatus())
+	require.Zero(t, newServerError("E999999").HTTPStatus())
+	require.Equal(t, "E999999", LookupErrorCode("E999999").Code)
+}
+
+func TestRateLimitHelpers(t *testing.T) {
+	err := ErrRateLimitExceeded.WithInfo(ErrorInfoKeys.RateLimitExceededRetryAfter, 10)
+	require.True(t, IsRateLimited(err))
+	require.True(t, IsRateLimited(fmt.Errorf("wrapped: %w", err)))
+	require.True(t, IsRateLimited(newServerError("E999999").WithInfo(ErrorInfoKeys.HTTPResponseStatusCode, http.StatusTooManyRequests)))
+	require.False(t, IsRateLimited(ErrBadRequest))
+	require.False(t, IsRateLimited(assert.AnError))
+	require.False(t, IsRateLimited(nil))
+
+	require.Equal(t, 10*time.Second, RetryAfter(err))
+	require.Equal(t, 10*time.Second, RetryAfter(fmt.Errorf("wrapped: %w", err)))
+	require.Zero(t, RetryAfter(ErrRateLimitExceeded))
+	require.Zero(t, RetryAfter(assert.AnError))
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	require.True(t, IsRetryable(ErrRateLimitExceeded))
+	require.True(t, IsRetryable(newServerError("E999999").WithInfo(ErrorInfoKeys.HTTPResponseStatusCode, http.StatusServiceUnavailable)))
+	require.True(t, IsRetryable(fmt.Errorf("wrapped: %w", timeoutError{})))
+	require.False(t, IsRetryable(ErrBadRequest.WithInfo(ErrorInfoKeys.HTTPResponseStatusCode, http.StatusBadRequest)))
+	require.False(t, IsRetryable(assert.AnError))
+	require.False(t, IsRetryable(nil))
+}
+
+func TestErrorCause(t *testing.T) {
+	cause := fmt.Errorf("root cause")
+	err := ErrInvalidResponse.WithCause(cause)
+	require.ErrorIs(t, err, ErrInvalidResponse)
+	require.ErrorIs(t, err, cause)
+	require.Equal(t, cause, errors.Unwrap(err))
+	require.Equal(t, "[G020002] Invalid server response: root cause", err.Error())
+	require.Nil(t, ErrInvalidResponse.Cause)
+
+	err = err.WithMessage("foo")
+	require.ErrorIs(t, err, cause)
+	require.Equal(t, "[G020002] Invalid server response: foo", err.Error())
+
+	var de *Error
+	require.Nil(t, de.Unwrap())
+	require.Nil(t, ErrBadRequest.Unwrap())
+
+	wrapped := ErrPublicKey.WithCause(ErrInvalidToken.WithCause(context.DeadlineExceeded))
+	require.ErrorIs(t, wrapped, ErrPublicKey)
+	require.ErrorIs(t, wrapped, ErrInvalidToken)
+	require.ErrorIs(t, wrapped, context.DeadlineExceeded)
+	require.NotErrorIs(t, wrapped, ErrInvalidResponse)
+}
@@ -23,6 +23,14 @@ type AuthParams struct {
 	PublicKey           string
 	SessionJWTViaCookie bool
 	CookieDomain        string
+	// Loads the tenants for resolving SSO tenants by email domain locally, when
+	// nil tenants are resolved by the server instead
+	TenantsLoader   func() ([]*descope.Tenant, error)
+	TenantsCacheTTL time.Duration
+	// Enables OAuth state protection, by signing the states stored in cookies or
+	// by saving the states in a shared store
+	OAuthStateSecret string
+	OAuthStateStore  sdk.OAuthStateStore
 }
 
 type authenticationsBase struct {
@@ -38,9 +46,12 @@ type authenticationService struct {
 	magicLink     sdk.MagicLink
 	enchantedLink sdk.EnchantedLink
 	totp          sdk.TOTP
+	password      sdk.Password
+	flow          sdk.Flow
 	webAuthn      sdk.WebAuthn
 	oauth         sdk.OAuth
 	saml          sdk.SAML
+	sso           sdk.SSOServiceProvider
 }
 
 func NewAuth(conf AuthParams, c *api.Client) (*authenticationService, error) {
@@ -52,8 +63,11 @@ func NewAuth(conf AuthParams, c *api.Client) (*authenticationService, error) {
 	authenticationService.enchantedLink = &enchantedLink{authenticationsBase: base}
 	authenticationService.oauth = &oauth{authenticationsBase: base}
 	authenticationService.saml = &saml{authenticationsBase: base}
+	authenticationService.sso = newSSO(base)
 	authenticationService.webAuthn = &webAuthn{authenticationsBase: base}
 	authenticationService.totp = &totp{authenticationsBase: base}
+	authenticationService.password = &password{authenticationsBase: base}
+	authenticationService.flow = &flow{authenticationsBase: base}
 	return authenticationService, nil
 }
 
@@ -73,6 +87,14 @@ func (auth *authenticationService) TOTP() sdk.TOTP {
 	return auth.totp
 }
 
+func (auth *authenticationService) Password() sdk.Password {
+	return auth.password
+}
+
+func (auth *authenticationService) Flow() sdk.Flow {
+	return auth.flow
+}
+
 func (auth *authenticationService) OAuth() sdk.OAuth {
 	return auth.oauth
 }
@@ -81,6 +103,10 @@ func (auth *authenticationService) SAML() sdk.SAML {
 	return auth.saml
 }
 
+func (auth *authenticationService) SSO() sdk.SSOServiceProvider {
+	return auth.sso
+}
+
 func (auth *authenticationService) WebAuthn() sdk.WebAuthn {
 	return auth.webAuthn
 }
@@ -450,7 +476,7 @@ func (auth *authenticationsBase) validateJWT(JWT string) (*descope.Token, error)
 		if descope.ErrInvalidToken.Is(err) {
 			err = descope.ErrPublicKey
 		} else if !descope.ErrPublicKey.Is(err) {
-			err = descope.ErrPublicKey.WithMessage("%s", err.Error())
+			err = descope.ErrPublicKey.WithMessage("%s", err.Error()).WithCause(err)
 		}
 	}
 
@@ -470,7 +496,7 @@ func (*authenticationsBase) verifyDeliveryMethod(method descope.DeliveryMethod,
 		} else {
 			varName = "user.Email"
 		}
-		if !emailRegex.MatchString(user.Email) {
+		if !utils.EmailRegex.MatchString(user.Email) {
 			return utils.NewInvalidArgumentError(varName)
 		}
 	case descope.MethodSMS:
@@ -479,7 +505,7 @@ func (*authenticationsBase) verifyDeliveryMethod(method descope.DeliveryMethod,
 		} else {
 			varName = "user.Phone"
 		}
-		if !phoneRegex.MatchString(user.Phone) {
+		if !utils.PhoneRegex.MatchString(user.Phone) {
 			return utils.NewInvalidArgumentError(varName)
 		}
 	case descope.MethodWhatsApp:
@@ -488,7 +514,7 @@ func (*authenticationsBase) verifyDeliveryMethod(method descope.DeliveryMethod,
 		} else {
 			varName = "user.Phone"
 		}
-		if !phoneRegex.MatchString(user.Phone) {
+		if !utils.PhoneRegex.MatchString(user.Phone) {
 			return utils.NewInvalidArgumentError(varName)
 		}
 	}
@@ -618,14 +644,14 @@ func provideTokens(r *http.Request) (string, string) {
 
 func convertTokenError(err error) error {
 	if goErrors.Is(err, jwt.ErrTokenExpired()) {
-		return descope.ErrInvalidToken.WithMessage("Token has expired")
+		return descope.ErrInvalidToken.WithMessage("Token has expired").WithCause(err)
 	}
 	if goErrors.Is(err, jwt.ErrTokenNotYetValid()) {
-		return descope.ErrInvalidToken.WithMessage("Token is not yet valid")
+		return descope.ErrInvalidToken.WithMessage("Token is not yet valid").WithCause(err)
 	}
 	var validationErr jwt.ValidationError
 	if goErrors.As(err, &validationErr) {
-		return descope.ErrInvalidToken
+		return descope.ErrInvalidToken.WithCause(err)
 	}
 	if err != nil {
 		if unwrapped := goErrors.Unwrap(err); unwrapped != nil {
@@ -633,7 +659,7 @@ func convertTokenError(err error) error {
 				return de
 			}
 		}
-		return descope.ErrInvalidToken.WithMessage("Failed to verify token: %s", err.Error())
+		return descope.ErrInvalidToken.WithMessage("Failed to verify token: %s", err.Error()).WithCause(err)
 	}
 	return nil
 }
@@ -706,6 +732,38 @@ func composeUpdateTOTPURL() string {
 	return api.Routes.UpdateTOTP()
 }
 
+func composeSignUpPasswordURL() string {
+	return api.Routes.SignUpPassword()
+}
+
+func composeSignInPasswordURL() string {
+	return api.Routes.SignInPassword()
+}
+
+func composeSendPasswordResetURL() string {
+	return api.Routes.SendPasswordReset()
+}
+
+func composeUpdateUserPasswordURL() string {
+	return api.Routes.UpdateUserPassword()
+}
+
+func composeReplaceUserPasswordURL() string {
+	return api.Routes.ReplaceUserPassword()
+}
+
+func composePasswordPolicyURL() string {
+	return api.Routes.PasswordPolicy()
+}
+
+func composeFlowStartURL() string {
+	return api.Routes.FlowStart()
+}
+
+func composeFlowNextURL() string {
+	return api.Routes.FlowNext()
+}
+
 func composeVerifyCodeURL(method descope.DeliveryMethod) string {
 	return composeURLMethod(api.Routes.VerifyCode(), method)
 }
@@ -770,6 +828,18 @@ func composeSAMLExchangeTokenURL() string {
 	return api.Routes.ExchangeTokenSAML()
 }
 
+func composeSSOStartURL() string {
+	return api.Routes.SSOStart()
+}
+
+func composeSSOExchangeTokenURL() string {
+	return api.Routes.ExchangeTokenSSO()
+}
+
+func composeSSOTenantURL() string {
+	return api.Routes.SSOTenant()
+}
+
 func composeUpdateUserEmailOTP() string {
 	return api.Routes.UpdateUserEmailOTP()
 }
@@ -185,6 +185,10 @@ func TestEmptyPublicKey(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorIs(t, err, descope.ErrPublicKey)
 	assert.Contains(t, err.Error(), descope.ErrInvalidResponse.Description)
+	// the underlying causes are preserved
+	assert.ErrorIs(t, err, descope.ErrInvalidResponse)
+	var jsonErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, err, &jsonErr)
 }
 
 func TestErrorFetchPublicKey(t *testing.T) {
@@ -83,7 +83,7 @@ func (auth *enchantedLink) UpdateUserEmail(loginID, email, URI string, r *http.R
 	if email == "" {
 		return nil, utils.NewInvalidArgumentError("email")
 	}
-	if !emailRegex.MatchString(email) {
+	if !utils.EmailRegex.MatchString(email) {
 		return nil, utils.NewInvalidArgumentError("email")
 	}
 	pswd, err := getValidRefreshToken(r)
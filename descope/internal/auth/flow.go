@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/api"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/logger"
+)
+
+type flow struct {
+	authenticationsBase
+}
+
+type flowResponseBody struct {
+	descope.FlowResponse
+	Status   string          `json:"status,omitempty"`
+	AuthInfo json.RawMessage `json:"authInfo,omitempty"`
+}
+
+func (auth *flow) Start(flowID string, input map[string]any, w http.ResponseWriter) (*descope.FlowResponse, error) {
+	if flowID == "" {
+		return nil, utils.NewInvalidArgumentError("flowID")
+	}
+	httpResponse, err := auth.client.DoPostRequest(composeFlowStartURL(), newFlowStartRequestBody(flowID, input), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return auth.generateFlowResponse(httpResponse, w)
+}
+
+func (auth *flow) Next(executionID, stepID, interactionID string, input map[string]any, w http.ResponseWriter) (*descope.FlowResponse, error) {
+	if executionID == "" {
+		return nil, utils.NewInvalidArgumentError("executionID")
+	}
+	if stepID == "" {
+		return nil, utils.NewInvalidArgumentError("stepID")
+	}
+	httpResponse, err := auth.client.DoPostRequest(composeFlowNextURL(), newFlowNextRequestBody(executionID, stepID, interactionID, input), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return auth.generateFlowResponse(httpResponse, w)
+}
+
+func (auth *flow) generateFlowResponse(httpResponse *api.HTTPResponse, w http.ResponseWriter) (*descope.FlowResponse, error) {
+	body := &flowResponseBody{}
+	if err := utils.Unmarshal([]byte(httpResponse.BodyStr), body); err != nil {
+		logger.LogError("Failed to parse flow response", err)
+		return nil, err
+	}
+	res := &body.FlowResponse
+	if body.Status == string(descope.FlowActionCompleted) {
+		res.Action = descope.FlowActionCompleted
+	}
+	if res.Action == descope.FlowActionCompleted {
+		if len(body.AuthInfo) == 0 {
+			return nil, descope.ErrInvalidResponse.WithMessage("Missing authentication info in completed flow response")
+		}
+		// the authentication info is handled the same as in any other sign in response
+		authResponse := &api.HTTPResponse{Req: httpResponse.Req, Res: httpResponse.Res, BodyStr: string(body.AuthInfo)}
+		authInfo, err := auth.generateAuthenticationInfo(authResponse, w)
+		if err != nil {
+			return nil, err
+		}
+		res.AuthInfo = authInfo
+	}
+	return res, nil
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowStart(t *testing.T) {
+	a, err := newTestAuth(nil, DoOkWithBody(func(r *http.Request) {
+		assert.EqualValues(t, composeFlowStartURL(), r.URL.RequestURI())
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, "sign-up-or-in", body["flowId"])
+		assert.EqualValues(t, map[string]any{"email": "dev@example.com"}, body["input"])
+	}, map[string]any{
+		"executionId": "e1",
+		"stepId":      "s1",
+		"status":      "running",
+		"action":      "screen",
+		"screen":      map[string]any{"id": "welcome", "state": map[string]any{"email": "dev@example.com"}, "interactions": []string{"submit"}},
+	}))
+	require.NoError(t, err)
+	res, err := a.Flow().Start("sign-up-or-in", map[string]any{"email": "dev@example.com"}, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, &descope.FlowResponse{
+		ExecutionID: "e1",
+		StepID:      "s1",
+		Action:      descope.FlowActionScreen,
+		Screen:      &descope.FlowScreen{ID: "welcome", State: map[string]any{"email": "dev@example.com"}, Interactions: []string{"submit"}},
+	}, res)
+}
+
+func TestFlowStartFailure(t *testing.T) {
+	a, err := newTestAuth(nil, DoBadRequest(nil))
+	require.NoError(t, err)
+	_, err = a.Flow().Start("", nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Flow().Start("sign-up-or-in", nil, nil)
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+}
+
+func TestFlowNextRedirectAndWebAuthn(t *testing.T) {
+	responses := []map[string]any{
+		{"executionId": "e1", "stepId": "s2", "action": "redirect", "redirect": map[string]any{"url": "https://idp.example.com"}},
+		{"executionId": "e1", "stepId": "s3", "action": "webauthn", "webauthn": map[string]any{"transactionId": "tx", "options": "{}", "create": true}},
+	}
+	calls := 0
+	a, err := newTestAuth(nil, func(r *http.Request) (*http.Response, error) {
+		assert.EqualValues(t, composeFlowNextURL(), r.URL.RequestURI())
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, "e1", body["executionId"])
+		assert.EqualValues(t, "s1", body["stepId"])
+		assert.EqualValues(t, "submit", body["interactionId"])
+		calls++
+		return DoOkWithBody(nil, responses[calls-1])(r)
+	})
+	require.NoError(t, err)
+	res, err := a.Flow().Next("e1", "s1", "submit", map[string]any{"email": "dev@example.com"}, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, descope.FlowActionRedirect, res.Action)
+	assert.EqualValues(t, "https://idp.example.com", res.Redirect.URL)
+
+	res, err = a.Flow().Next("e1", "s1", "submit", nil, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, descope.FlowActionWebAuthn, res.Action)
+	assert.EqualValues(t, &descope.FlowWebAuthn{TransactionID: "tx", Options: "{}", Create: true}, res.WebAuthn)
+	assert.Nil(t, res.AuthInfo)
+}
+
+func TestFlowNextCompleted(t *testing.T) {
+	body := fmt.Sprintf(`{"executionId": "e1", "stepId": "s4", "status": "completed", "authInfo": %s}`, mockAuthSessionBody)
+	a, err := newTestAuth(nil, func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	res, err := a.Flow().Next("e1", "s3", "", map[string]any{"response": "..."}, w)
+	require.NoError(t, err)
+	assert.EqualValues(t, descope.FlowActionCompleted, res.Action)
+	require.NotNil(t, res.AuthInfo)
+	assert.EqualValues(t, jwtTokenValid, res.AuthInfo.SessionToken.JWT)
+	assert.NotEmpty(t, w.Result().Cookies())
+}
+
+func TestFlowNextFailure(t *testing.T) {
+	a, err := newTestAuth(nil, DoBadRequest(nil))
+	require.NoError(t, err)
+	_, err = a.Flow().Next("", "s1", "", nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Flow().Next("e1", "", "", nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Flow().Next("e1", "s1", "", nil, nil)
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+
+	a, err = newTestAuth(nil, DoOkWithBody(nil, map[string]any{"status": "completed", "authInfo": map[string]any{"sessionJwt": "invalid"}}))
+	require.NoError(t, err)
+	_, err = a.Flow().Next("e1", "s1", "", nil, nil)
+	assert.Error(t, err)
+
+	a, err = newTestAuth(nil, DoOkWithBody(nil, map[string]any{"status": "completed"}))
+	require.NoError(t, err)
+	_, err = a.Flow().Next("e1", "s1", "", nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidResponse)
+}
@@ -37,7 +37,7 @@ func (p *provider) selectKey(sink jws.KeySink, key jwk.Key) error {
 	if v := key.Algorithm(); v.String() != "" {
 		var alg jwa.SignatureAlgorithm
 		if err := alg.Accept(v); err != nil {
-			return descope.ErrPublicKey.WithMessage("Invalid signature algorithm %s: %s", key.Algorithm(), err.Error())
+			return descope.ErrPublicKey.WithMessage("Invalid signature algorithm %s: %s", key.Algorithm(), err.Error()).WithCause(err)
 		}
 
 		sink.Key(alg, key)
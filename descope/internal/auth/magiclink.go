@@ -65,7 +65,7 @@ func (auth *magicLink) UpdateUserEmail(loginID, email, URI string, r *http.Reque
 	if email == "" {
 		return utils.NewInvalidArgumentError("email")
 	}
-	if !emailRegex.MatchString(email) {
+	if !utils.EmailRegex.MatchString(email) {
 		return utils.NewInvalidArgumentError("email")
 	}
 	pswd, err := getValidRefreshToken(r)
@@ -83,7 +83,7 @@ func (auth *magicLink) UpdateUserPhone(method descope.DeliveryMethod, loginID, p
 	if phone == "" {
 		return utils.NewInvalidArgumentError("phone")
 	}
-	if !phoneRegex.MatchString(phone) {
+	if !utils.PhoneRegex.MatchString(phone) {
 		return utils.NewInvalidArgumentError("phone")
 	}
 	if method != descope.MethodSMS && method != descope.MethodWhatsApp {
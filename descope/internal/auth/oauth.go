@@ -1,7 +1,17 @@
 package auth
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/api"
@@ -9,6 +19,12 @@ import (
 	"github.com/descope/go-sdk/descope/logger"
 )
 
+// How long the user has to complete an OAuth authentication when state protection is enabled
+const oauthStateTTL = 10 * time.Minute
+
+// Built-in providers and the names of custom providers configured in the console
+var oauthProviderRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9 ._-]{0,99}$`)
+
 type oauth struct {
 	authenticationsBase
 }
@@ -17,13 +33,40 @@ type oauthStartResponse struct {
 	URL string `json:"url"`
 }
 
+type oauthStartBody struct {
+	*descope.LoginOptions
+	Scopes     []string          `json:"scopes,omitempty"`
+	Prompt     string            `json:"prompt,omitempty"`
+	LoginHint  string            `json:"loginHint,omitempty"`
+	AuthParams map[string]string `json:"authParams,omitempty"`
+}
+
 func (auth *oauth) Start(provider descope.OAuthProvider, redirectURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (url string, err error) {
+	return auth.StartWithOptions(provider, redirectURL, r, loginOptions, nil, w)
+}
+
+func (auth *oauth) StartWithOptions(provider descope.OAuthProvider, redirectURL string, r *http.Request, loginOptions *descope.LoginOptions, options *descope.OAuthOptions, w http.ResponseWriter) (url string, err error) {
+	if !oauthProviderRegex.MatchString(string(provider)) {
+		return "", utils.NewInvalidArgumentError("provider")
+	}
+	if options == nil {
+		options = &descope.OAuthOptions{}
+	}
+	if auth.isStateEnabled() {
+		if redirectURL, err = auth.createState(redirectURL, options.CodeChallenge, w); err != nil {
+			return "", err
+		}
+	}
 	m := map[string]string{
 		"provider": string(provider),
 	}
 	if len(redirectURL) > 0 {
 		m["redirectURL"] = redirectURL
 	}
+	if options.CodeChallenge != "" {
+		m["codeChallenge"] = options.CodeChallenge
+		m["codeChallengeMethod"] = descope.PKCEMethodS256
+	}
 	var pswd string
 	if loginOptions.IsJWTRequired() {
 		pswd, err = getValidRefreshToken(r)
@@ -32,7 +75,11 @@ func (auth *oauth) Start(provider descope.OAuthProvider, redirectURL string, r *
 		}
 	}
 
-	httpResponse, err := auth.client.DoPostRequest(composeOAuthURL(), loginOptions, &api.HTTPRequest{QueryParams: m}, pswd)
+	var body any = loginOptions
+	if len(options.Scopes) > 0 || options.Prompt != "" || options.LoginHint != "" || len(options.AuthParams) > 0 {
+		body = &oauthStartBody{LoginOptions: loginOptions, Scopes: options.Scopes, Prompt: options.Prompt, LoginHint: options.LoginHint, AuthParams: options.AuthParams}
+	}
+	httpResponse, err := auth.client.DoPostRequest(composeOAuthURL(), body, &api.HTTPRequest{QueryParams: m}, pswd)
 	if err != nil {
 		return
 	}
@@ -52,5 +99,143 @@ func (auth *oauth) Start(provider descope.OAuthProvider, redirectURL string, r *
 }
 
 func (auth *oauth) ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if auth.isStateEnabled() {
+		return nil, descope.ErrInvalidOAuthState.WithMessage("OAuth state protection is enabled, use ExchangeTokenWithOptions to verify the state")
+	}
 	return auth.exchangeToken(code, composeOAuthExchangeTokenURL(), w)
 }
+
+func (auth *oauth) ExchangeTokenWithOptions(code string, r *http.Request, options *descope.OAuthExchangeOptions, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if code == "" {
+		return nil, utils.NewInvalidArgumentError("code")
+	}
+	if options == nil {
+		options = &descope.OAuthExchangeOptions{}
+	}
+	if auth.isStateEnabled() {
+		if err := auth.verifyState(r, options.CodeVerifier, w); err != nil {
+			return nil, err
+		}
+	}
+	body := newExchangeTokenBody(code)
+	body.CodeVerifier = options.CodeVerifier
+	httpResponse, err := auth.client.DoPostRequest(composeOAuthExchangeTokenURL(), body, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return auth.generateAuthenticationInfo(httpResponse, w)
+}
+
+func (auth *oauth) isStateEnabled() bool {
+	return auth.conf.OAuthStateSecret != "" || auth.conf.OAuthStateStore != nil
+}
+
+// Creates a new state and saves it, and returns the redirectURL with the state added to it.
+//
+// The state is formatted as nonce.expiration.challenge.signature, where the challenge is only
+// set when the authentication is started with PKCE and the signature only when a secret is
+// configured. PKCE binds the state to the client that started the authentication, otherwise
+// it's bound to the user's browser with a cookie.
+func (auth *oauth) createState(redirectURL, codeChallenge string, w http.ResponseWriter) (string, error) {
+	u, err := url.Parse(redirectURL)
+	if redirectURL == "" || err != nil {
+		return "", utils.NewInvalidArgumentError("redirectURL")
+	}
+	if codeChallenge == "" && w == nil {
+		return "", utils.NewInvalidArgumentError("w")
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err // notest
+	}
+	expiration := time.Now().Add(oauthStateTTL)
+	state := base64.RawURLEncoding.EncodeToString(nonce) + "." + strconv.FormatInt(expiration.Unix(), 10) + "." + codeChallenge
+	state += "." + auth.signState(state)
+
+	if auth.conf.OAuthStateStore != nil {
+		if err := auth.conf.OAuthStateStore.Save(state, expiration); err != nil {
+			return "", err
+		}
+	}
+	if codeChallenge == "" {
+		// the callback is a top level navigation from another site, so the cookie
+		// can't be strict or it won't be sent with it
+		http.SetCookie(w, &http.Cookie{
+			Name:     descope.OAuthStateCookieName,
+			Value:    state,
+			Path:     "/",
+			Domain:   auth.conf.CookieDomain,
+			Expires:  expiration,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+
+	query := u.Query()
+	query.Set(descope.OAuthStateParamName, state)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Verifies the state in the callback request was created by this application for the same
+// browser or PKCE client, and hasn't expired or been used already
+func (auth *oauth) verifyState(r *http.Request, codeVerifier string, w http.ResponseWriter) error {
+	if r == nil {
+		return descope.ErrInvalidOAuthState.WithMessage("The callback request is required to verify the OAuth state")
+	}
+	state := r.URL.Query().Get(descope.OAuthStateParamName)
+	if state == "" {
+		return descope.ErrInvalidOAuthState.WithMessage("Missing OAuth state in the callback request")
+	}
+
+	parts := strings.Split(state, ".")
+	if len(parts) != 4 {
+		return descope.ErrInvalidOAuthState.WithMessage("Malformed OAuth state")
+	}
+	if !hmac.Equal([]byte(parts[3]), []byte(auth.signState(strings.Join(parts[:3], ".")))) {
+		return descope.ErrInvalidOAuthState.WithMessage("Invalid OAuth state signature")
+	}
+	expiration, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expiration {
+		return descope.ErrInvalidOAuthState.WithMessage("Expired OAuth state")
+	}
+
+	if challenge := parts[2]; challenge != "" {
+		// the authentication was started with PKCE, so only the same client can provide the verifier
+		if codeVerifier == "" || subtle.ConstantTimeCompare([]byte(descope.PKCEChallenge(codeVerifier)), []byte(challenge)) != 1 {
+			return descope.ErrInvalidOAuthState.WithMessage("The code verifier doesn't match the OAuth state")
+		}
+	} else {
+		cookie, err := r.Cookie(descope.OAuthStateCookieName)
+		if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+			return descope.ErrInvalidOAuthState.WithMessage("The OAuth state doesn't match the state cookie")
+		}
+		if w != nil {
+			// the state can only be used once
+			http.SetCookie(w, &http.Cookie{Name: descope.OAuthStateCookieName, Path: "/", Domain: auth.conf.CookieDomain, MaxAge: -1, HttpOnly: true, Secure: true})
+		}
+	}
+
+	if auth.conf.OAuthStateStore != nil {
+		found, err := auth.conf.OAuthStateStore.Consume(state)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return descope.ErrInvalidOAuthState.WithMessage("Unknown or already used OAuth state")
+		}
+	}
+	return nil
+}
+
+// Returns an empty signature when there's no secret, in which case the state is only
+// valid if it's found in the store
+func (auth *oauth) signState(payload string) string {
+	if auth.conf.OAuthStateSecret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(auth.conf.OAuthStateSecret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
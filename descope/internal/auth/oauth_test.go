@@ -9,10 +9,12 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/api"
 	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -157,3 +159,249 @@ func TestExchangeTokenError(t *testing.T) {
 	_, err = a.OAuth().ExchangeToken(code, w)
 	require.Error(t, err)
 }
+
+type testOAuthStateStore struct {
+	states map[string]time.Time
+}
+
+func (s *testOAuthStateStore) Save(state string, expiration time.Time) error {
+	s.states[state] = expiration
+	return nil
+}
+
+func (s *testOAuthStateStore) Consume(state string) (bool, error) {
+	_, ok := s.states[state]
+	delete(s.states, state)
+	return ok, nil
+}
+
+func doOAuthWithState(uri string, checks func(*http.Request)) mocks.Do {
+	redirect := DoRedirect(uri, checks)
+	exchange := DoOkWithBody(checks, &descope.JWTResponse{RefreshJwt: jwtTokenValid})
+	return func(r *http.Request) (*http.Response, error) {
+		if strings.HasPrefix(r.URL.RequestURI(), composeOAuthExchangeTokenURL()) {
+			return exchange(r)
+		}
+		return redirect(r)
+	}
+}
+
+func callbackRequest(w *httptest.ResponseRecorder, returnURL string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, returnURL, nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+	return r
+}
+
+func TestOAuthStateCookie(t *testing.T) {
+	landingURL := "https://test.com/callback?a=b"
+	var redirectURL string
+	a, err := newTestAuthConf(&AuthParams{ProjectID: "a", PublicKey: publicKey, OAuthStateSecret: "secret"}, nil, doOAuthWithState("http://test.me", func(r *http.Request) {
+		if strings.HasPrefix(r.URL.RequestURI(), composeOAuthURL()+"?") {
+			redirectURL = r.URL.Query().Get("redirectURL")
+		}
+	}))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	_, err = a.OAuth().StartWithOptions(descope.OAuthGithub, landingURL, nil, nil, nil, w)
+	require.NoError(t, err)
+	u, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+	assert.Equal(t, "b", u.Query().Get("a"))
+	state := u.Query().Get(descope.OAuthStateParamName)
+	require.NotEmpty(t, state)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, descope.OAuthStateCookieName, cookies[0].Name)
+	assert.Equal(t, state, cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+
+	// the old method can't verify the state
+	_, err = a.OAuth().ExchangeToken("code", httptest.NewRecorder())
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+
+	w2 := httptest.NewRecorder()
+	authInfo, err := a.OAuth().ExchangeTokenWithOptions("code", callbackRequest(w, redirectURL), nil, w2)
+	require.NoError(t, err)
+	require.NotNil(t, authInfo)
+	cleared := false
+	for _, c := range w2.Result().Cookies() {
+		if c.Name == descope.OAuthStateCookieName {
+			cleared = c.MaxAge < 0
+		}
+	}
+	assert.True(t, cleared)
+}
+
+func TestOAuthStateCookieMismatch(t *testing.T) {
+	var redirectURL string
+	a, err := newTestAuthConf(&AuthParams{ProjectID: "a", PublicKey: publicKey, OAuthStateSecret: "secret"}, nil, doOAuthWithState("http://test.me", func(r *http.Request) {
+		if strings.HasPrefix(r.URL.RequestURI(), composeOAuthURL()+"?") {
+			redirectURL = r.URL.Query().Get("redirectURL")
+		}
+	}))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	_, err = a.OAuth().StartWithOptions(descope.OAuthGithub, "https://test.com", nil, nil, nil, w)
+	require.NoError(t, err)
+
+	// no cookie
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", httptest.NewRequest(http.MethodGet, redirectURL, nil), nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+	// no state
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", callbackRequest(w, "https://test.com"), nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+	// no request
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+	// tampered state
+	u, _ := url.Parse(redirectURL)
+	parts := strings.Split(u.Query().Get(descope.OAuthStateParamName), ".")
+	require.Len(t, parts, 4)
+	tampered := parts[0] + "." + fmt.Sprint(time.Now().Add(time.Hour).Unix()) + "." + parts[2] + "." + parts[3]
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", callbackRequest(w, "https://test.com?state="+tampered), nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+	// a code verifier doesn't replace the cookie when the authentication wasn't started with PKCE
+	r := httptest.NewRequest(http.MethodGet, redirectURL, nil)
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", r, &descope.OAuthExchangeOptions{CodeVerifier: "verifier"}, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+}
+
+func TestOAuthStateMissingReturnURL(t *testing.T) {
+	a, err := newTestAuthConf(&AuthParams{ProjectID: "a", PublicKey: publicKey, OAuthStateSecret: "secret"}, nil, nil)
+	require.NoError(t, err)
+	_, err = a.OAuth().StartWithOptions(descope.OAuthGithub, "", nil, nil, nil, httptest.NewRecorder())
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.OAuth().StartWithOptions(descope.OAuthGithub, "https://test.com", nil, nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestOAuthStateStore(t *testing.T) {
+	store := &testOAuthStateStore{states: map[string]time.Time{}}
+	var redirectURL string
+	a, err := newTestAuthConf(&AuthParams{ProjectID: "a", PublicKey: publicKey, OAuthStateStore: store}, nil, doOAuthWithState("http://test.me", func(r *http.Request) {
+		if strings.HasPrefix(r.URL.RequestURI(), composeOAuthURL()+"?") {
+			redirectURL = r.URL.Query().Get("redirectURL")
+		}
+	}))
+	require.NoError(t, err)
+	// the state is bound to the browser with a cookie in store mode as well
+	_, err = a.OAuth().StartWithOptions(descope.OAuthGoogle, "https://test.com", nil, nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	w := httptest.NewRecorder()
+	_, err = a.OAuth().StartWithOptions(descope.OAuthGoogle, "https://test.com", nil, nil, nil, w)
+	require.NoError(t, err)
+	require.Len(t, store.states, 1)
+
+	// a valid state from another browser is rejected and remains in the store
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", httptest.NewRequest(http.MethodGet, redirectURL, nil), nil, httptest.NewRecorder())
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+	require.Len(t, store.states, 1)
+
+	r := callbackRequest(w, redirectURL)
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", r, nil, httptest.NewRecorder())
+	require.NoError(t, err)
+	assert.Empty(t, store.states)
+
+	// states can only be used once
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", r, nil, httptest.NewRecorder())
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+}
+
+func TestOAuthPKCE(t *testing.T) {
+	pkce, err := descope.NewPKCE()
+	require.NoError(t, err)
+	var redirectURL string
+	a, err := newTestAuthConf(&AuthParams{ProjectID: "a", PublicKey: publicKey, OAuthStateSecret: "secret"}, nil, doOAuthWithState("http://test.me", func(r *http.Request) {
+		if strings.HasPrefix(r.URL.RequestURI(), composeOAuthExchangeTokenURL()) {
+			body, err := readBodyMap(r)
+			require.NoError(t, err)
+			assert.EqualValues(t, map[string]any{"code": "code", "codeVerifier": pkce.Verifier}, body)
+		} else {
+			redirectURL = r.URL.Query().Get("redirectURL")
+			assert.Equal(t, pkce.Challenge, r.URL.Query().Get("codeChallenge"))
+			assert.Equal(t, descope.PKCEMethodS256, r.URL.Query().Get("codeChallengeMethod"))
+		}
+	}))
+	require.NoError(t, err)
+	// native clients don't have cookies, so there's no response writer
+	_, err = a.OAuth().StartWithOptions(descope.OAuthGithub, "https://test.com", nil, nil, &descope.OAuthOptions{CodeChallenge: pkce.Challenge}, nil)
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodGet, redirectURL, nil)
+
+	// the state is bound to the code challenge
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", r, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+	other, err := descope.NewPKCE()
+	require.NoError(t, err)
+	_, err = a.OAuth().ExchangeTokenWithOptions("code", r, &descope.OAuthExchangeOptions{CodeVerifier: other.Verifier}, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidOAuthState)
+
+	// the code verifier proves the exchange is done by the same client, without a cookie
+	authInfo, err := a.OAuth().ExchangeTokenWithOptions("code", r, &descope.OAuthExchangeOptions{CodeVerifier: pkce.Verifier}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, authInfo)
+}
+
+func TestExchangeTokenWithOptionsEmptyCode(t *testing.T) {
+	a, err := newTestAuth(nil, nil)
+	require.NoError(t, err)
+	_, err = a.OAuth().ExchangeTokenWithOptions("", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestOAuthStartCustomProvider(t *testing.T) {
+	provider := descope.OAuthProvider("My Provider")
+	a, err := newTestAuth(nil, DoRedirect("http://test.me", func(r *http.Request) {
+		assert.EqualValues(t, string(provider), r.URL.Query().Get("provider"))
+	}))
+	require.NoError(t, err)
+	_, err = a.OAuth().Start(provider, "", nil, nil, httptest.NewRecorder())
+	require.NoError(t, err)
+}
+
+func TestOAuthStartInvalidProvider(t *testing.T) {
+	a, err := newTestAuth(nil, nil)
+	require.NoError(t, err)
+	for _, provider := range []descope.OAuthProvider{"", " google", "google?x=y", descope.OAuthProvider(strings.Repeat("a", 101))} {
+		_, err = a.OAuth().Start(provider, "", nil, nil, httptest.NewRecorder())
+		assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	}
+}
+
+func TestOAuthStartWithOptions(t *testing.T) {
+	a, err := newTestAuth(nil, DoRedirect("http://test.me", func(r *http.Request) {
+		assert.EqualValues(t, descope.OAuthSlack, r.URL.Query().Get("provider"))
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, map[string]any{
+			"stepup":     true,
+			"scopes":     []any{"users:read", "chat:write"},
+			"prompt":     "consent",
+			"loginHint":  "dev@example.com",
+			"authParams": map[string]any{"team": "T123"},
+		}, body)
+	}))
+	require.NoError(t, err)
+	options := &descope.OAuthOptions{Scopes: []string{"users:read", "chat:write"}, Prompt: "consent", LoginHint: "dev@example.com", AuthParams: map[string]string{"team": "T123"}}
+	r := &http.Request{Header: http.Header{"Cookie": []string{"DSR=test"}}}
+	_, err = a.OAuth().StartWithOptions(descope.OAuthSlack, "", r, &descope.LoginOptions{Stepup: true}, options, httptest.NewRecorder())
+	require.NoError(t, err)
+}
+
+func TestExchangeTokenProviderTokens(t *testing.T) {
+	a, err := newTestAuth(nil, DoOkWithBody(nil, &descope.JWTResponse{
+		RefreshJwt:     jwtTokenValid,
+		ProviderTokens: &descope.ProviderTokens{Provider: "discord", AccessToken: "at", Expiration: 123, Scopes: []string{"identify"}},
+	}))
+	require.NoError(t, err)
+	authInfo, err := a.OAuth().ExchangeToken("code", httptest.NewRecorder())
+	require.NoError(t, err)
+	require.NotNil(t, authInfo.ProviderTokens)
+	assert.Equal(t, "discord", authInfo.ProviderTokens.Provider)
+	assert.Equal(t, "at", authInfo.ProviderTokens.AccessToken)
+	assert.EqualValues(t, 123, authInfo.ProviderTokens.Expiration)
+	assert.Equal(t, []string{"identify"}, authInfo.ProviderTokens.Scopes)
+}
@@ -54,11 +54,11 @@ func (auth *otp) VerifyCode(method descope.DeliveryMethod, loginID string, code
 		return nil, utils.NewInvalidArgumentError("loginID")
 	}
 	if method == "" {
-		if phoneRegex.MatchString(loginID) {
+		if utils.PhoneRegex.MatchString(loginID) {
 			method = descope.MethodSMS
 		}
 
-		if emailRegex.MatchString(loginID) {
+		if utils.EmailRegex.MatchString(loginID) {
 			method = descope.MethodEmail
 		}
 
@@ -80,7 +80,7 @@ func (auth *otp) UpdateUserEmail(loginID, email string, r *http.Request) error {
 	if email == "" {
 		return utils.NewInvalidArgumentError("email")
 	}
-	if !emailRegex.MatchString(email) {
+	if !utils.EmailRegex.MatchString(email) {
 		return utils.NewInvalidArgumentError("email")
 	}
 	pswd, err := getValidRefreshToken(r)
@@ -98,7 +98,7 @@ func (auth *otp) UpdateUserPhone(method descope.DeliveryMethod, loginID, phone s
 	if phone == "" {
 		return utils.NewInvalidArgumentError("phone")
 	}
-	if !phoneRegex.MatchString(phone) {
+	if !utils.PhoneRegex.MatchString(phone) {
 		return utils.NewInvalidArgumentError("phone")
 	}
 	if method != descope.MethodSMS && method != descope.MethodWhatsApp {
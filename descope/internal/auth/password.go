@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/api"
+	"github.com/descope/go-sdk/descope/internal/utils"
+)
+
+type password struct {
+	authenticationsBase
+}
+
+func (auth *password) SignUp(loginID string, user *descope.User, password string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if loginID == "" {
+		return nil, utils.NewInvalidArgumentError("loginID")
+	}
+	if password == "" {
+		return nil, utils.NewInvalidArgumentError("password")
+	}
+	httpResponse, err := auth.client.DoPostRequest(composeSignUpPasswordURL(), newPasswordSignUpRequestBody(loginID, user, password), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return auth.generateAuthenticationInfo(httpResponse, w)
+}
+
+func (auth *password) SignIn(loginID, password string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if loginID == "" {
+		return nil, utils.NewInvalidArgumentError("loginID")
+	}
+	if password == "" {
+		return nil, utils.NewInvalidArgumentError("password")
+	}
+	var pswd string
+	var err error
+	if loginOptions.IsJWTRequired() {
+		pswd, err = getValidRefreshToken(r)
+		if err != nil {
+			return nil, descope.ErrInvalidStepUpJWT
+		}
+	}
+	httpResponse, err := auth.client.DoPostRequest(composeSignInPasswordURL(), newPasswordSignInRequestBody(loginID, password, loginOptions), nil, pswd)
+	if err != nil {
+		return nil, err
+	}
+	return auth.generateAuthenticationInfo(httpResponse, w)
+}
+
+func (auth *password) SendPasswordReset(loginID, redirectURL string) error {
+	if loginID == "" {
+		return utils.NewInvalidArgumentError("loginID")
+	}
+	_, err := auth.client.DoPostRequest(composeSendPasswordResetURL(), newPasswordResetRequestBody(loginID, redirectURL), nil, "")
+	return err
+}
+
+func (auth *password) UpdatePassword(loginID, newPassword string, r *http.Request) error {
+	if loginID == "" {
+		return utils.NewInvalidArgumentError("loginID")
+	}
+	if newPassword == "" {
+		return utils.NewInvalidArgumentError("newPassword")
+	}
+	pswd, err := getValidRefreshToken(r)
+	if err != nil {
+		return err
+	}
+	_, err = auth.client.DoPostRequest(composeUpdateUserPasswordURL(), newPasswordUpdateRequestBody(loginID, newPassword), nil, pswd)
+	return err
+}
+
+func (auth *password) ReplacePassword(loginID, oldPassword, newPassword string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if loginID == "" {
+		return nil, utils.NewInvalidArgumentError("loginID")
+	}
+	if oldPassword == "" {
+		return nil, utils.NewInvalidArgumentError("oldPassword")
+	}
+	if newPassword == "" {
+		return nil, utils.NewInvalidArgumentError("newPassword")
+	}
+	httpResponse, err := auth.client.DoPostRequest(composeReplaceUserPasswordURL(), newPasswordReplaceRequestBody(loginID, oldPassword, newPassword), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return auth.generateAuthenticationInfo(httpResponse, w)
+}
+
+func (auth *password) GetPolicy() (*descope.PasswordPolicy, error) {
+	policy := &descope.PasswordPolicy{}
+	_, err := auth.client.DoGetRequest(composePasswordPolicyURL(), &api.HTTPRequest{ResBodyObj: policy}, "")
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
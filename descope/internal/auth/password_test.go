@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignUpPassword(t *testing.T) {
+	loginID := "someID"
+	a, err := newTestAuth(nil, DoOk(func(r *http.Request) {
+		assert.EqualValues(t, composeSignUpPasswordURL(), r.URL.RequestURI())
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, loginID, body["loginId"])
+		assert.EqualValues(t, "secret", body["password"])
+		assert.EqualValues(t, "test", body["user"].(map[string]any)["name"])
+	}))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	info, err := a.Password().SignUp(loginID, &descope.User{Name: "test"}, "secret", w)
+	require.NoError(t, err)
+	assert.EqualValues(t, jwtTokenValid, info.SessionToken.JWT)
+	assert.NotEmpty(t, w.Result().Cookies())
+}
+
+func TestSignUpPasswordFailure(t *testing.T) {
+	a, err := newTestAuth(nil, DoBadRequest(nil))
+	require.NoError(t, err)
+	_, err = a.Password().SignUp("", nil, "secret", nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Password().SignUp("someID", nil, "", nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Password().SignUp("someID", nil, "secret", nil)
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+}
+
+func TestSignInPassword(t *testing.T) {
+	loginID := "someID"
+	a, err := newTestAuth(nil, DoOk(func(r *http.Request) {
+		assert.EqualValues(t, composeSignInPasswordURL(), r.URL.RequestURI())
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, loginID, body["loginId"])
+		assert.EqualValues(t, "secret", body["password"])
+		assert.Nil(t, body["loginOptions"])
+	}))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	info, err := a.Password().SignIn(loginID, "secret", nil, nil, w)
+	require.NoError(t, err)
+	assert.EqualValues(t, jwtTokenValid, info.SessionToken.JWT)
+	assert.NotEmpty(t, w.Result().Cookies())
+}
+
+func TestSignInPasswordStepup(t *testing.T) {
+	a, err := newTestAuth(nil, DoOk(func(r *http.Request) {
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, map[string]any{"stepup": true}, body["loginOptions"])
+		assert.EqualValues(t, api.BearerAuthorizationPrefix+"a:test", r.Header.Get(api.AuthorizationHeaderName))
+	}))
+	require.NoError(t, err)
+	r := &http.Request{Header: http.Header{"Cookie": []string{"DSR=test"}}}
+	_, err = a.Password().SignIn("someID", "secret", r, &descope.LoginOptions{Stepup: true}, nil)
+	require.NoError(t, err)
+
+	_, err = a.Password().SignIn("someID", "secret", &http.Request{Header: http.Header{}}, &descope.LoginOptions{Stepup: true}, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidStepUpJWT)
+}
+
+func TestSignInPasswordFailure(t *testing.T) {
+	a, err := newTestAuth(nil, DoBadRequest(nil))
+	require.NoError(t, err)
+	_, err = a.Password().SignIn("", "secret", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Password().SignIn("someID", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Password().SignIn("someID", "secret", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+}
+
+func TestSendPasswordReset(t *testing.T) {
+	a, err := newTestAuth(nil, DoOk(func(r *http.Request) {
+		assert.EqualValues(t, composeSendPasswordResetURL(), r.URL.RequestURI())
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, "someID", body["loginId"])
+		assert.EqualValues(t, "https://example.com/reset", body["redirectUrl"])
+	}))
+	require.NoError(t, err)
+	err = a.Password().SendPasswordReset("someID", "https://example.com/reset")
+	require.NoError(t, err)
+	err = a.Password().SendPasswordReset("", "")
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestUpdatePassword(t *testing.T) {
+	a, err := newTestAuth(nil, DoOk(func(r *http.Request) {
+		assert.EqualValues(t, composeUpdateUserPasswordURL(), r.URL.RequestURI())
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, "someID", body["loginId"])
+		assert.EqualValues(t, "new", body["newPassword"])
+		assert.EqualValues(t, api.BearerAuthorizationPrefix+"a:"+jwtTokenValid, r.Header.Get(api.AuthorizationHeaderName))
+	}))
+	require.NoError(t, err)
+	r := &http.Request{Header: http.Header{}}
+	r.AddCookie(&http.Cookie{Name: descope.RefreshCookieName, Value: jwtTokenValid})
+	err = a.Password().UpdatePassword("someID", "new", r)
+	require.NoError(t, err)
+}
+
+func TestUpdatePasswordFailure(t *testing.T) {
+	a, err := newTestAuth(nil, DoOk(nil))
+	require.NoError(t, err)
+	r := &http.Request{Header: http.Header{}}
+	err = a.Password().UpdatePassword("", "new", r)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	err = a.Password().UpdatePassword("someID", "", r)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	err = a.Password().UpdatePassword("someID", "new", r)
+	assert.ErrorIs(t, err, descope.ErrRefreshToken)
+}
+
+func TestReplacePassword(t *testing.T) {
+	a, err := newTestAuth(nil, DoOk(func(r *http.Request) {
+		assert.EqualValues(t, composeReplaceUserPasswordURL(), r.URL.RequestURI())
+		body, err := readBodyMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, "someID", body["loginId"])
+		assert.EqualValues(t, "old", body["oldPassword"])
+		assert.EqualValues(t, "new", body["newPassword"])
+	}))
+	require.NoError(t, err)
+	info, err := a.Password().ReplacePassword("someID", "old", "new", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, jwtTokenValid, info.SessionToken.JWT)
+}
+
+func TestReplacePasswordFailure(t *testing.T) {
+	a, err := newTestAuth(nil, DoBadRequest(nil))
+	require.NoError(t, err)
+	_, err = a.Password().ReplacePassword("", "old", "new", nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Password().ReplacePassword("someID", "", "new", nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Password().ReplacePassword("someID", "old", "", nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.Password().ReplacePassword("someID", "old", "new", nil)
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+}
+
+func TestGetPasswordPolicy(t *testing.T) {
+	a, err := newTestAuth(nil, DoOkWithBody(func(r *http.Request) {
+		assert.EqualValues(t, composePasswordPolicyURL(), r.URL.RequestURI())
+		assert.EqualValues(t, http.MethodGet, r.Method)
+	}, map[string]any{"minLength": 8, "uppercase": true, "lock": true, "lockAttempts": 5}))
+	require.NoError(t, err)
+	policy, err := a.Password().GetPolicy()
+	require.NoError(t, err)
+	assert.EqualValues(t, &descope.PasswordPolicy{MinLength: 8, Uppercase: true, Lock: true, LockAttempts: 5}, policy)
+
+	a, err = newTestAuth(nil, DoBadRequest(nil))
+	require.NoError(t, err)
+	_, err = a.Password().GetPolicy()
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+}
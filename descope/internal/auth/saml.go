@@ -4,53 +4,14 @@ import (
 	"net/http"
 
 	"github.com/descope/go-sdk/descope"
-	"github.com/descope/go-sdk/descope/api"
-	"github.com/descope/go-sdk/descope/internal/utils"
-	"github.com/descope/go-sdk/descope/logger"
 )
 
 type saml struct {
 	authenticationsBase
 }
 
-type samlStartResponse struct {
-	URL string `json:"url"`
-}
-
 func (auth *saml) Start(tenant string, redirectURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (url string, err error) {
-	if tenant == "" {
-		return "", utils.NewInvalidArgumentError("tenant")
-	}
-	m := map[string]string{
-		"tenant": string(tenant),
-	}
-	if len(redirectURL) > 0 {
-		m["redirectURL"] = redirectURL
-	}
-	var pswd string
-	if loginOptions.IsJWTRequired() {
-		pswd, err = getValidRefreshToken(r)
-		if err != nil {
-			return "", descope.ErrInvalidStepUpJWT
-		}
-	}
-	httpResponse, err := auth.client.DoPostRequest(composeSAMLStartURL(), loginOptions, &api.HTTPRequest{QueryParams: m}, pswd)
-	if err != nil {
-		return
-	}
-
-	if httpResponse.Res != nil {
-		res := &samlStartResponse{}
-		err = utils.Unmarshal([]byte(httpResponse.BodyStr), res)
-		if err != nil {
-			logger.LogError("Failed to parse saml location from response for [%s]", err, tenant)
-			return "", err
-		}
-		url = res.URL
-		redirectToURL(url, w)
-	}
-
-	return
+	return auth.startSSO(composeSAMLStartURL(), tenant, redirectURL, r, loginOptions, w)
 }
 
 func (auth *saml) ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
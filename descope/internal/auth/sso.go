@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/api"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/logger"
+)
+
+type sso struct {
+	authenticationsBase
+	index *tenantDomainIndex
+}
+
+func newSSO(base authenticationsBase) *sso {
+	s := &sso{authenticationsBase: base}
+	if base.conf.TenantsLoader != nil {
+		s.index = &tenantDomainIndex{load: base.conf.TenantsLoader, ttl: base.conf.TenantsCacheTTL}
+	}
+	return s
+}
+
+type ssoStartResponse struct {
+	URL string `json:"url"`
+}
+
+func (auth *sso) Start(tenant string, redirectURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (url string, err error) {
+	return auth.startSSO(composeSSOStartURL(), tenant, redirectURL, r, loginOptions, w)
+}
+
+func (auth *sso) StartByEmail(email string, redirectURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (url string, err error) {
+	domain := emailDomain(email)
+	if domain == "" {
+		return "", utils.NewInvalidArgumentError("email")
+	}
+	var tenantIDs []string
+	if auth.index != nil {
+		tenantIDs, err = auth.index.resolve(domain)
+	} else {
+		tenantIDs, err = auth.resolveTenants(email)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(tenantIDs) == 0 {
+		return "", descope.ErrSSOTenantNotFound.WithMessage("No SSO tenant matches the domain %s", domain)
+	}
+	if len(tenantIDs) > 1 {
+		return "", descope.ErrSSOMultipleTenants.WithMessage("The domain %s matches the tenants %s", domain, strings.Join(tenantIDs, ", "))
+	}
+	return auth.Start(tenantIDs[0], redirectURL, r, loginOptions, w)
+}
+
+// Asks the server for the tenants whose self provisioning domains match the email
+func (auth *sso) resolveTenants(email string) ([]string, error) {
+	httpResponse, err := auth.client.DoPostRequest(composeSSOTenantURL(), map[string]any{"email": email}, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	res := struct {
+		TenantIDs []string `json:"tenantIds"`
+	}{}
+	if err := utils.Unmarshal([]byte(httpResponse.BodyStr), &res); err != nil {
+		logger.LogError("Failed to parse sso tenants from response for [%s]", err, email)
+		return nil, err
+	}
+	return res.TenantIDs, nil
+}
+
+func (auth *sso) ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	return auth.exchangeToken(code, composeSSOExchangeTokenURL(), w)
+}
+
+// Starts an SSO flow for a tenant with either the SAML or the protocol agnostic start URL
+func (auth *authenticationsBase) startSSO(startURL string, tenant string, redirectURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (url string, err error) {
+	if tenant == "" {
+		return "", utils.NewInvalidArgumentError("tenant")
+	}
+	m := map[string]string{
+		"tenant": string(tenant),
+	}
+	if len(redirectURL) > 0 {
+		m["redirectURL"] = redirectURL
+	}
+	var pswd string
+	if loginOptions.IsJWTRequired() {
+		pswd, err = getValidRefreshToken(r)
+		if err != nil {
+			return "", descope.ErrInvalidStepUpJWT
+		}
+	}
+	httpResponse, err := auth.client.DoPostRequest(startURL, loginOptions, &api.HTTPRequest{QueryParams: m}, pswd)
+	if err != nil {
+		return
+	}
+
+	if httpResponse.Res != nil {
+		res := &ssoStartResponse{}
+		err = utils.Unmarshal([]byte(httpResponse.BodyStr), res)
+		if err != nil {
+			logger.LogError("Failed to parse sso location from response for [%s]", err, tenant)
+			return "", err
+		}
+		url = res.URL
+		redirectToURL(url, w)
+	}
+
+	return
+}
+
+// An index of tenants by their self provisioning domains, which is loaded on first
+// use and reloaded when it's older than the ttl
+type tenantDomainIndex struct {
+	load func() ([]*descope.Tenant, error)
+	ttl  time.Duration
+
+	mutex    sync.Mutex
+	loadedAt time.Time
+	domains  map[string][]string
+}
+
+func (i *tenantDomainIndex) resolve(domain string) ([]string, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.domains == nil || (i.ttl > 0 && time.Since(i.loadedAt) > i.ttl) {
+		tenants, err := i.load()
+		if err != nil {
+			return nil, err
+		}
+		domains := map[string][]string{}
+		for _, tenant := range tenants {
+			for _, d := range tenant.SelfProvisioningDomains {
+				d = strings.ToLower(strings.TrimSpace(d))
+				domains[d] = append(domains[d], tenant.ID)
+			}
+		}
+		i.domains = domains
+		i.loadedAt = time.Now()
+	}
+	return i.domains[domain], nil
+}
+
+func emailDomain(email string) string {
+	if !utils.EmailRegex.MatchString(email) {
+		return ""
+	}
+	return strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSOStart(t *testing.T) {
+	uri := "http://test.me"
+	tenant := "tenantID"
+	landingURL := "https://test.com"
+	a, err := newTestAuth(nil, DoRedirect(uri, func(r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "auth/sso/authorize"))
+		assert.EqualValues(t, fmt.Sprintf("%s?redirectURL=%s&tenant=%s", composeSSOStartURL(), url.QueryEscape(landingURL), tenant), r.URL.RequestURI())
+		assert.Nil(t, r.Body)
+	}))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	urlStr, err := a.SSO().Start(tenant, landingURL, nil, nil, w)
+	require.NoError(t, err)
+	assert.EqualValues(t, uri, urlStr)
+	assert.EqualValues(t, urlStr, w.Result().Header.Get(descope.RedirectLocationCookieName))
+	assert.EqualValues(t, http.StatusTemporaryRedirect, w.Result().StatusCode)
+}
+
+func TestSSOStartInvalidForwardResponse(t *testing.T) {
+	a, err := newTestAuth(nil, nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	_, err = a.SSO().Start("", "", nil, nil, w)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	_, err = a.SSO().Start("test", "", nil, &descope.LoginOptions{Stepup: true}, w)
+	assert.ErrorIs(t, err, descope.ErrInvalidStepUpJWT)
+}
+
+func TestExchangeTokenSSO(t *testing.T) {
+	code := "code"
+	a, err := newTestAuth(nil, func(r *http.Request) (*http.Response, error) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "auth/sso/exchange"))
+		req := exchangeTokenBody{}
+		err := readBody(r, &req)
+		require.NoError(t, err)
+		assert.EqualValues(t, code, req.Code)
+		resp := &descope.JWTResponse{
+			RefreshJwt: jwtTokenValid,
+			User:       &descope.UserResponse{User: descope.User{Name: "name"}},
+		}
+		respBytes, err := utils.Marshal(resp)
+		require.NoError(t, err)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(respBytes))}, nil
+	})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	authInfo, err := a.SSO().ExchangeToken(code, w)
+	require.NoError(t, err)
+	require.NotNil(t, authInfo)
+	assert.EqualValues(t, "name", authInfo.User.Name)
+}
+
+func doSSOByEmail(t *testing.T, tenantIDs []string, uri string) func(r *http.Request) (*http.Response, error) {
+	return func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "auth/sso/tenant") {
+			body, err := readBodyMap(r)
+			require.NoError(t, err)
+			assert.EqualValues(t, "john@Acme.com", body["email"])
+			return DoOkWithBody(nil, map[string]any{"tenantIds": tenantIDs})(r)
+		}
+		assert.EqualValues(t, fmt.Sprintf("%s?tenant=%s", composeSSOStartURL(), tenantIDs[0]), r.URL.RequestURI())
+		return DoRedirect(uri, nil)(r)
+	}
+}
+
+func TestSSOStartByEmail(t *testing.T) {
+	uri := "http://test.me"
+	a, err := newTestAuth(nil, doSSOByEmail(t, []string{"t1"}, uri))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	urlStr, err := a.SSO().StartByEmail("john@Acme.com", "", nil, nil, w)
+	require.NoError(t, err)
+	assert.EqualValues(t, uri, urlStr)
+	assert.EqualValues(t, http.StatusTemporaryRedirect, w.Result().StatusCode)
+}
+
+func TestSSOStartByEmailNoMatch(t *testing.T) {
+	a, err := newTestAuth(nil, doSSOByEmail(t, nil, ""))
+	require.NoError(t, err)
+	_, err = a.SSO().StartByEmail("john@Acme.com", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrSSOTenantNotFound)
+	assert.Contains(t, err.Error(), "acme.com")
+
+	a, err = newTestAuth(nil, doSSOByEmail(t, []string{"t1", "t2"}, ""))
+	require.NoError(t, err)
+	_, err = a.SSO().StartByEmail("john@Acme.com", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrSSOMultipleTenants)
+	assert.Contains(t, err.Error(), "t1, t2")
+}
+
+func TestSSOStartByEmailError(t *testing.T) {
+	a, err := newTestAuth(nil, DoBadRequest(nil))
+	require.NoError(t, err)
+	_, err = a.SSO().StartByEmail("invalid", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = a.SSO().StartByEmail("john@acme.com", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrBadRequest)
+}
+
+func TestSSOStartByEmailLocalIndex(t *testing.T) {
+	loads := 0
+	var loadErr error
+	authParams := &AuthParams{ProjectID: "a", PublicKey: publicKey, TenantsCacheTTL: time.Hour, TenantsLoader: func() ([]*descope.Tenant, error) {
+		loads++
+		return []*descope.Tenant{
+			{ID: "t1", SelfProvisioningDomains: []string{"Acme.com"}},
+			{ID: "t2", SelfProvisioningDomains: []string{"shared.com"}},
+			{ID: "t3", SelfProvisioningDomains: []string{"shared.com", "other.com"}},
+		}, loadErr
+	}}
+	uri := "http://test.me"
+	a, err := newTestAuthConf(authParams, nil, DoRedirect(uri, func(r *http.Request) {
+		assert.EqualValues(t, fmt.Sprintf("%s?redirectURL=%s&tenant=t1", composeSSOStartURL(), url.QueryEscape("https://test.com")), r.URL.RequestURI())
+	}))
+	require.NoError(t, err)
+	urlStr, err := a.SSO().StartByEmail("john@acme.com", "https://test.com", nil, nil, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, uri, urlStr)
+
+	_, err = a.SSO().StartByEmail("john@shared.com", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrSSOMultipleTenants)
+	_, err = a.SSO().StartByEmail("john@example.com", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrSSOTenantNotFound)
+	assert.Equal(t, 1, loads)
+
+	// reloaded when the cache expires
+	a.sso.(*sso).index.loadedAt = time.Now().Add(-2 * time.Hour)
+	loadErr = descope.ErrRateLimitExceeded
+	_, err = a.SSO().StartByEmail("john@acme.com", "", nil, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrRateLimitExceeded)
+	assert.Equal(t, 2, loads)
+}
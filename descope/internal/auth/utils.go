@@ -1,8 +1,6 @@
 package auth
 
 import (
-	"regexp"
-
 	"github.com/descope/go-sdk/descope"
 )
 
@@ -55,6 +53,46 @@ type totpSignUpRequestBody struct {
 	User    *descope.User `json:"user,omitempty"`
 }
 
+type passwordSignUpRequestBody struct {
+	LoginID  string        `json:"loginId,omitempty"`
+	User     *descope.User `json:"user,omitempty"`
+	Password string        `json:"password,omitempty"`
+}
+
+type passwordSignInRequestBody struct {
+	LoginID      string                `json:"loginId,omitempty"`
+	Password     string                `json:"password,omitempty"`
+	LoginOptions *descope.LoginOptions `json:"loginOptions,omitempty"`
+}
+
+type passwordResetRequestBody struct {
+	LoginID     string `json:"loginId,omitempty"`
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
+
+type passwordUpdateRequestBody struct {
+	LoginID     string `json:"loginId,omitempty"`
+	NewPassword string `json:"newPassword,omitempty"`
+}
+
+type passwordReplaceRequestBody struct {
+	LoginID     string `json:"loginId,omitempty"`
+	OldPassword string `json:"oldPassword,omitempty"`
+	NewPassword string `json:"newPassword,omitempty"`
+}
+
+type flowStartRequestBody struct {
+	FlowID string         `json:"flowId,omitempty"`
+	Input  map[string]any `json:"input,omitempty"`
+}
+
+type flowNextRequestBody struct {
+	ExecutionID   string         `json:"executionId,omitempty"`
+	StepID        string         `json:"stepId,omitempty"`
+	InteractionID string         `json:"interactionId,omitempty"`
+	Input         map[string]any `json:"input,omitempty"`
+}
+
 type otpUpdateEmailRequestBody struct {
 	LoginID string `json:"loginId,omitempty"`
 	Email   string `json:"email,omitempty"`
@@ -101,7 +139,8 @@ type authenticationGetMagicLinkSessionBody struct {
 }
 
 type exchangeTokenBody struct {
-	Code string `json:"code"`
+	Code         string `json:"code"`
+	CodeVerifier string `json:"codeVerifier,omitempty"`
 }
 
 func newSignInRequestBody(loginID string, loginOptions *descope.LoginOptions) *authenticationRequestBody {
@@ -123,6 +162,34 @@ func newSignUPTOTPRequestBody(loginID string, user *descope.User) *totpSignUpReq
 	return &totpSignUpRequestBody{LoginID: loginID, User: user}
 }
 
+func newPasswordSignUpRequestBody(loginID string, user *descope.User, password string) *passwordSignUpRequestBody {
+	return &passwordSignUpRequestBody{LoginID: loginID, User: user, Password: password}
+}
+
+func newPasswordSignInRequestBody(loginID, password string, loginOptions *descope.LoginOptions) *passwordSignInRequestBody {
+	return &passwordSignInRequestBody{LoginID: loginID, Password: password, LoginOptions: loginOptions}
+}
+
+func newPasswordResetRequestBody(loginID, redirectURL string) *passwordResetRequestBody {
+	return &passwordResetRequestBody{LoginID: loginID, RedirectURL: redirectURL}
+}
+
+func newPasswordUpdateRequestBody(loginID, newPassword string) *passwordUpdateRequestBody {
+	return &passwordUpdateRequestBody{LoginID: loginID, NewPassword: newPassword}
+}
+
+func newPasswordReplaceRequestBody(loginID, oldPassword, newPassword string) *passwordReplaceRequestBody {
+	return &passwordReplaceRequestBody{LoginID: loginID, OldPassword: oldPassword, NewPassword: newPassword}
+}
+
+func newFlowStartRequestBody(flowID string, input map[string]any) *flowStartRequestBody {
+	return &flowStartRequestBody{FlowID: flowID, Input: input}
+}
+
+func newFlowNextRequestBody(executionID, stepID, interactionID string, input map[string]any) *flowNextRequestBody {
+	return &flowNextRequestBody{ExecutionID: executionID, StepID: stepID, InteractionID: interactionID, Input: input}
+}
+
 func newOTPUpdateEmailRequestBody(loginID, email string) *otpUpdateEmailRequestBody {
 	return &otpUpdateEmailRequestBody{LoginID: loginID, Email: email}
 }
@@ -182,8 +249,3 @@ const (
 	claimPermissions   = "permissions"
 	claimRoles         = "roles"
 )
-
-var (
-	phoneRegex = regexp.MustCompile(`^(?:(?:\(?(?:00|\+)([1-4]\d\d|[1-9]\d?)\)?)?[\-\.\ \\\/]?)?((?:\(?\d{1,}\)?[\-\.\ \\\/]?){0,})(?:[\-\.\ \\\/]?(?:#|ext\.?|extension|x)[\-\.\ \\\/]?(\d+))?$`)
-	emailRegex = regexp.MustCompile("^(((([a-zA-Z]|\\d|[!#\\$%&'\\*\\+\\-\\/=\\?\\^_`{\\|}~]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])+(\\.([a-zA-Z]|\\d|[!#\\$%&'\\*\\+\\-\\/=\\?\\^_`{\\|}~]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])+)*)|((\\x22)((((\\x20|\\x09)*(\\x0d\\x0a))?(\\x20|\\x09)+)?(([\\x01-\\x08\\x0b\\x0c\\x0e-\\x1f\\x7f]|\\x21|[\\x23-\\x5b]|[\\x5d-\\x7e]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(\\([\\x01-\\x09\\x0b\\x0c\\x0d-\\x7f]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}]))))*(((\\x20|\\x09)*(\\x0d\\x0a))?(\\x20|\\x09)+)?(\\x22)))@((([a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(([a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])([a-zA-Z]|\\d|-|\\.|_|~|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])*([a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])))\\.)+(([a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(([a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])([a-zA-Z]|\\d|-|_|~|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])*([a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])))\\.?$")
-)
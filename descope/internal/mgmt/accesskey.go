@@ -1,6 +1,8 @@
 package mgmt
 
 import (
+	"time"
+
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/api"
 	"github.com/descope/go-sdk/descope/internal/utils"
@@ -11,10 +13,17 @@ type accessKey struct {
 }
 
 func (a *accessKey) Create(name string, expireTime int64, roleNames []string, keyTenants []*descope.AssociatedTenant) (string, *descope.AccessKeyResponse, error) {
-	if name == "" {
+	return a.CreateWithOptions(&descope.AccessKeyRequest{Name: name, ExpireTime: expireTime, RoleNames: roleNames, KeyTenants: keyTenants})
+}
+
+func (a *accessKey) CreateWithOptions(key *descope.AccessKeyRequest) (string, *descope.AccessKeyResponse, error) {
+	if key == nil {
+		return "", nil, utils.NewInvalidArgumentError("key")
+	}
+	if key.Name == "" {
 		return "", nil, utils.NewInvalidArgumentError("name")
 	}
-	body := makeCreateAccessKeyBody(name, expireTime, roleNames, keyTenants)
+	body := makeCreateAccessKeyBody(key)
 	res, err := a.client.DoPostRequest(api.Routes.ManagementAccessKeyCreate(), body, nil, a.conf.ManagementKey)
 	if err != nil {
 		return "", nil, err
@@ -46,13 +55,20 @@ func (a *accessKey) SearchAll(tenantIDs []string) ([]*descope.AccessKeyResponse,
 }
 
 func (a *accessKey) Update(id, name string) (*descope.AccessKeyResponse, error) {
+	return a.UpdateWithOptions(id, &descope.AccessKeyRequest{Name: name})
+}
+
+func (a *accessKey) UpdateWithOptions(id string, key *descope.AccessKeyRequest) (*descope.AccessKeyResponse, error) {
 	if id == "" {
 		return nil, utils.NewInvalidArgumentError("id")
 	}
-	if name == "" {
+	if key == nil {
+		return nil, utils.NewInvalidArgumentError("key")
+	}
+	if key.Name == "" {
 		return nil, utils.NewInvalidArgumentError("name")
 	}
-	body := map[string]any{"id": id, "name": name}
+	body := makeUpdateAccessKeyBody(id, key)
 	res, err := a.client.DoPostRequest(api.Routes.ManagementAccessKeyUpdate(), body, nil, a.conf.ManagementKey)
 	if err != nil {
 		return nil, err
@@ -87,13 +103,79 @@ func (a *accessKey) Delete(id string) error {
 	return err
 }
 
-func makeCreateAccessKeyBody(name string, expireTime int64, roleNames []string, tenants []*descope.AssociatedTenant) map[string]any {
-	return map[string]any{
-		"name":       name,
-		"expireTime": expireTime,
-		"roleNames":  roleNames,
-		"keyTenants": makeAssociatedTenantList(tenants),
+func (a *accessKey) Rotate(id string, gracePeriod time.Duration) (*descope.AccessKeyRotation, error) {
+	old, err := a.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if old == nil {
+		return nil, descope.ErrInvalidResponse.WithMessage("Missing access key in response") // notest
+	}
+
+	var expireTime int64
+	if old.ExpireTime > 0 && old.CreatedTime > 0 && old.ExpireTime > old.CreatedTime {
+		expireTime = time.Now().Unix() + old.ExpireTime - old.CreatedTime
+	}
+	deactivateAt := time.Now()
+	if gracePeriod > 0 {
+		deactivateAt = deactivateAt.Add(gracePeriod)
+	}
+	cleartext, key, err := a.CreateWithOptions(&descope.AccessKeyRequest{
+		Name:         old.Name,
+		Description:  &old.Description,
+		ExpireTime:   expireTime,
+		RoleNames:    old.RoleNames,
+		KeyTenants:   old.KeyTenants,
+		CustomClaims: old.CustomClaims,
+		PermittedIPs: old.PermittedIPs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := &descope.AccessKeyRotation{Cleartext: cleartext, NewKey: key, OldKey: old, DeactivateAt: deactivateAt}
+	if gracePeriod <= 0 {
+		if err := a.Deactivate(id); err != nil {
+			return rotation, err
+		}
+	}
+	return rotation, nil
+}
+
+func makeCreateAccessKeyBody(key *descope.AccessKeyRequest) map[string]any {
+	body := map[string]any{
+		"name":         key.Name,
+		"expireTime":   key.ExpireTime,
+		"roleNames":    key.RoleNames,
+		"keyTenants":   makeAssociatedTenantList(key.KeyTenants),
+		"customClaims": key.CustomClaims,
+		"permittedIps": key.PermittedIPs,
+	}
+	if key.Description != nil {
+		body["description"] = *key.Description
+	}
+	return body
+}
+
+// Only the fields that are set are sent, so the server leaves the others as they are
+func makeUpdateAccessKeyBody(id string, key *descope.AccessKeyRequest) map[string]any {
+	body := map[string]any{"id": id, "name": key.Name}
+	if key.Description != nil {
+		body["description"] = *key.Description
+	}
+	if key.RoleNames != nil {
+		body["roleNames"] = key.RoleNames
+	}
+	if key.KeyTenants != nil {
+		body["keyTenants"] = makeAssociatedTenantList(key.KeyTenants)
+	}
+	if key.CustomClaims != nil {
+		body["customClaims"] = key.CustomClaims
+	}
+	if key.PermittedIPs != nil {
+		body["permittedIps"] = key.PermittedIPs
 	}
+	return body
 }
 
 func unmarshalCreatedAccessKeyResponse(res *api.HTTPResponse) (string, *descope.AccessKeyResponse, error) {
@@ -2,8 +2,11 @@ package mgmt
 
 import (
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/tests/helpers"
 	"github.com/stretchr/testify/require"
 )
@@ -19,21 +22,42 @@ func TestAccessKeyCreateSuccess(t *testing.T) {
 		req := map[string]any{}
 		require.NoError(t, helpers.ReadBody(r, &req))
 		require.Equal(t, "abc", req["name"])
+		require.Equal(t, "ci pipeline", req["description"])
 		require.EqualValues(t, 0, req["expireTime"])
 		roleNames := req["roleNames"].([]any)
 		require.Len(t, roleNames, 1)
 		require.Equal(t, "foo", roleNames[0])
+		require.Equal(t, map[string]any{"k1": "v1"}, req["customClaims"])
+		require.EqualValues(t, []any{"10.0.0.0/8"}, req["permittedIps"])
 	}, response))
-	cleartext, key, err := mgmt.AccessKey().Create("abc", 0, []string{"foo"}, nil)
+	cleartext, key, err := mgmt.AccessKey().CreateWithOptions(&descope.AccessKeyRequest{
+		Name:         "abc",
+		Description:  descope.Ptr("ci pipeline"),
+		RoleNames:    []string{"foo"},
+		CustomClaims: map[string]any{"k1": "v1"},
+		PermittedIPs: []string{"10.0.0.0/8"},
+	})
 	require.NoError(t, err)
 	require.Equal(t, "cleartext", cleartext)
 	require.Equal(t, "abc", key.Name)
+
+	mgmt = newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["name"])
+		require.EqualValues(t, 1700000000, req["expireTime"])
+		require.NotContains(t, req, "description")
+	}, response))
+	_, _, err = mgmt.AccessKey().Create("abc", 1700000000, nil, nil)
+	require.NoError(t, err)
 }
 
 func TestAccessKeyCreateError(t *testing.T) {
 	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
 	_, _, err := mgmt.AccessKey().Create("", 0, nil, nil)
 	require.Error(t, err)
+	_, _, err = mgmt.AccessKey().CreateWithOptions(nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
 }
 
 func TestAccessKeyLoadSuccess(t *testing.T) {
@@ -97,12 +121,17 @@ func TestSearchAllAccessKeysError(t *testing.T) {
 func TestAccessKeyUpdateSuccess(t *testing.T) {
 	response := map[string]any{
 		"key": map[string]any{
-			"id":   "ak1",
-			"name": "abc",
+			"id":          "ak1",
+			"name":        "abc",
+			"description": "ci pipeline",
 			"keyTenants": []map[string]any{{
 				"tenantId":  "t1",
 				"roleNames": []string{"role"},
 			}},
+			"createdTime":  1700000000,
+			"expireTime":   4102444800,
+			"customClaims": map[string]any{"k1": "v1"},
+			"permittedIps": []string{"10.0.0.1"},
 		}}
 	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
 		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
@@ -110,14 +139,40 @@ func TestAccessKeyUpdateSuccess(t *testing.T) {
 		require.NoError(t, helpers.ReadBody(r, &req))
 		require.Equal(t, "ak1", req["id"])
 		require.Equal(t, "abc", req["name"])
+		require.Equal(t, "ci pipeline", req["description"])
+		require.EqualValues(t, []any{map[string]any{"tenantId": "t1", "roleNames": []any{"role"}}}, req["keyTenants"])
+		require.EqualValues(t, []any{}, req["permittedIps"])
+		require.NotContains(t, req, "roleNames")
+		require.NotContains(t, req, "customClaims")
 	}, response))
-	res, err := mgmt.AccessKey().Update("ak1", "abc")
+	res, err := mgmt.AccessKey().UpdateWithOptions("ak1", &descope.AccessKeyRequest{
+		Name:         "abc",
+		Description:  descope.Ptr("ci pipeline"),
+		KeyTenants:   []*descope.AssociatedTenant{{TenantID: "t1", Roles: []string{"role"}}},
+		PermittedIPs: []string{},
+	})
 	require.NoError(t, err)
 	require.Equal(t, "ak1", res.ID)
 	require.Equal(t, "abc", res.Name)
 	require.Len(t, res.KeyTenants, 1)
 	require.Equal(t, "t1", res.KeyTenants[0].TenantID)
 	require.Equal(t, "role", res.KeyTenants[0].Roles[0])
+	require.Equal(t, "ci pipeline", res.Description)
+	require.EqualValues(t, 4102444800, res.ExpireTime)
+	require.EqualValues(t, 1700000000, res.CreatedTime)
+	require.Equal(t, "v1", res.CustomClaims["k1"])
+	require.EqualValues(t, []string{"10.0.0.1"}, res.PermittedIPs)
+}
+
+func TestAccessKeyUpdateNameOnly(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, map[string]any{"id": "ak1", "name": "abc"}, req)
+	}, map[string]any{"key": map[string]any{"id": "ak1"}}))
+	res, err := mgmt.AccessKey().Update("ak1", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "ak1", res.ID)
 }
 
 func TestAccessKeyUpdateError(t *testing.T) {
@@ -126,6 +181,8 @@ func TestAccessKeyUpdateError(t *testing.T) {
 	require.Error(t, err)
 	_, err = mgmt.AccessKey().Update("ak1", "")
 	require.Error(t, err)
+	_, err = mgmt.AccessKey().UpdateWithOptions("ak1", nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
 }
 
 func TestAccessKeyDeactivateSuccess(t *testing.T) {
@@ -178,3 +235,101 @@ func TestAccessKeyDeleteError(t *testing.T) {
 	err := mgmt.AccessKey().Delete("")
 	require.Error(t, err)
 }
+
+func doRotate(t *testing.T, loaded map[string]any, deactivated chan string) func(r *http.Request) (*http.Response, error) {
+	return func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "mgmt/accesskey"):
+			require.Equal(t, "ak1", r.URL.Query().Get("id"))
+			return helpers.DoOkWithBody(nil, map[string]any{"key": loaded})(r)
+		case strings.HasSuffix(r.URL.Path, "mgmt/accesskey/create"):
+			req := map[string]any{}
+			require.NoError(t, helpers.ReadBody(r, &req))
+			require.Equal(t, loaded["name"], req["name"])
+			if description, ok := loaded["description"]; ok {
+				require.Equal(t, description, req["description"])
+			}
+			if ips, ok := loaded["permittedIps"].([]string); ok {
+				require.EqualValues(t, []any{ips[0]}, req["permittedIps"])
+			}
+			require.EqualValues(t, []any{"admin"}, req["roleNames"])
+			// the rotation isn't recorded in the claims, which end up in the key's tokens
+			if claims, ok := loaded["customClaims"]; ok {
+				require.Equal(t, claims, req["customClaims"])
+			} else {
+				require.Nil(t, req["customClaims"])
+			}
+			expireTime := req["expireTime"].(float64)
+			if loaded["expireTime"] == nil {
+				require.Zero(t, expireTime)
+			} else {
+				require.InDelta(t, time.Now().Unix()+1000, expireTime, 5)
+			}
+			return helpers.DoOkWithBody(nil, map[string]any{"cleartext": "secret", "key": map[string]any{"id": "ak2", "name": req["name"]}})(r)
+		case strings.HasSuffix(r.URL.Path, "mgmt/accesskey/deactivate"):
+			req := map[string]any{}
+			require.NoError(t, helpers.ReadBody(r, &req))
+			deactivated <- req["id"].(string)
+			return helpers.DoOk(nil)(r)
+		}
+		require.Fail(t, "unexpected request", r.URL.Path)
+		return nil, nil
+	}
+}
+
+func TestAccessKeyRotateSuccess(t *testing.T) {
+	now := time.Now().Unix()
+	loaded := map[string]any{"id": "ak1", "name": "abc", "description": "ci", "roleNames": []string{"admin"}, "createdTime": now - 500, "expireTime": now + 500, "permittedIps": []string{"10.0.0.1"}}
+	deactivated := make(chan string, 1)
+	mgmt := newTestMgmt(nil, doRotate(t, loaded, deactivated))
+	rotation, err := mgmt.AccessKey().Rotate("ak1", 0)
+	require.NoError(t, err)
+	require.Equal(t, "secret", rotation.Cleartext)
+	require.Equal(t, "ak2", rotation.NewKey.ID)
+	require.Equal(t, "ak1", rotation.OldKey.ID)
+	require.WithinDuration(t, time.Now(), rotation.DeactivateAt, time.Second)
+	require.Equal(t, "ak1", <-deactivated)
+}
+
+func TestAccessKeyRotateGracePeriod(t *testing.T) {
+	loaded := map[string]any{"id": "ak1", "name": "abc", "roleNames": []string{"admin"}, "customClaims": map[string]any{"k1": "v1"}}
+	deactivated := make(chan string, 1)
+	mgmt := newTestMgmt(nil, doRotate(t, loaded, deactivated))
+	rotation, err := mgmt.AccessKey().Rotate("ak1", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "secret", rotation.Cleartext)
+	require.WithinDuration(t, time.Now().Add(time.Hour), rotation.DeactivateAt, time.Second)
+	// the old key is left for the caller to deactivate after the grace period
+	require.Empty(t, deactivated)
+	require.Equal(t, map[string]any{"k1": "v1"}, rotation.OldKey.CustomClaims)
+}
+
+func TestAccessKeyRotateError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := mgmt.AccessKey().Rotate("", 0)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	_, err = mgmt.AccessKey().Rotate("ak1", 0)
+	require.Error(t, err)
+
+	mgmt = newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "mgmt/accesskey") {
+			return helpers.DoOkWithBody(nil, map[string]any{"key": map[string]any{"id": "ak1", "name": "abc"}})(r)
+		}
+		return helpers.DoBadRequest(nil)(r)
+	})
+	_, err = mgmt.AccessKey().Rotate("ak1", 0)
+	require.Error(t, err)
+
+	mgmt = newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "mgmt/accesskey/deactivate") {
+			return helpers.DoBadRequest(nil)(r)
+		}
+		return helpers.DoOkWithBody(nil, map[string]any{"cleartext": "secret", "key": map[string]any{"id": "ak1", "name": "abc"}})(r)
+	})
+	rotation, err := mgmt.AccessKey().Rotate("ak1", 0)
+	require.Error(t, err)
+	require.Equal(t, "secret", rotation.Cleartext)
+}
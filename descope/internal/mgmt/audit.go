@@ -0,0 +1,171 @@
+package mgmt
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/api"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/sdk"
+)
+
+type audit struct {
+	managementBase
+}
+
+func (a *audit) Search(options *descope.AuditSearchOptions) sdk.AuditIterator {
+	it := &auditIterator{audit: a, options: descope.AuditSearchOptions{}}
+	if options != nil {
+		it.options = *options
+	}
+	return it
+}
+
+func (a *audit) Export(w io.Writer, options *descope.AuditSearchOptions) (int, error) {
+	count := 0
+	it := a.Search(options)
+	for it.Next() {
+		b, err := utils.Marshal(it.Record())
+		if err != nil { // notest
+			return count, err
+		}
+		if _, err := w.Write(append(b, '\n')); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, it.Err()
+}
+
+func (a *audit) searchPage(options *descope.AuditSearchOptions, cursor string) ([]*descope.AuditRecord, string, error) {
+	if options.Limit < 0 {
+		return nil, "", utils.NewInvalidArgumentError("limit")
+	}
+	if !options.From.IsZero() && !options.To.IsZero() && options.To.Before(options.From) {
+		return nil, "", utils.NewInvalidArgumentError("to")
+	}
+	res, err := a.client.DoPostRequest(api.Routes.ManagementAuditSearch(), makeAuditSearchRequest(options, cursor), nil, a.conf.ManagementKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return unmarshalAuditSearchResponse(res)
+}
+
+func makeAuditSearchRequest(options *descope.AuditSearchOptions, cursor string) map[string]any {
+	req := map[string]any{
+		"userIds":         options.UserIDs,
+		"loginIds":        options.LoginIDs,
+		"tenants":         options.Tenants,
+		"actions":         options.Actions,
+		"remoteAddresses": options.RemoteAddresses,
+	}
+	if !options.From.IsZero() {
+		req["from"] = options.From.UnixMilli()
+	}
+	if !options.To.IsZero() {
+		req["to"] = options.To.UnixMilli()
+	}
+	if options.Limit > 0 {
+		req["limit"] = options.Limit
+	}
+	if cursor != "" {
+		req["cursor"] = cursor
+	}
+	return req
+}
+
+type apiAuditRecord struct {
+	ProjectID     string         `json:"projectId"`
+	UserID        string         `json:"userId"`
+	Action        string         `json:"action"`
+	Occurred      json.Number    `json:"occurred"`
+	Device        string         `json:"device"`
+	Method        string         `json:"method"`
+	Geo           string         `json:"geo"`
+	RemoteAddress string         `json:"remoteAddress"`
+	ExternalIDs   []string       `json:"externalIds"`
+	Tenants       []string       `json:"tenants"`
+	Data          map[string]any `json:"data"`
+}
+
+func unmarshalAuditSearchResponse(res *api.HTTPResponse) ([]*descope.AuditRecord, string, error) {
+	ares := struct {
+		Audits     []*apiAuditRecord `json:"audits"`
+		NextCursor string            `json:"nextCursor"`
+	}{}
+	err := utils.Unmarshal([]byte(res.BodyStr), &ares)
+	if err != nil {
+		return nil, "", err
+	}
+	records := []*descope.AuditRecord{}
+	for _, r := range ares.Audits {
+		record := &descope.AuditRecord{
+			ProjectID:     r.ProjectID,
+			UserID:        r.UserID,
+			Action:        r.Action,
+			Device:        r.Device,
+			Method:        r.Method,
+			Geo:           r.Geo,
+			RemoteAddress: r.RemoteAddress,
+			LoginIDs:      r.ExternalIDs,
+			Tenants:       r.Tenants,
+			Data:          r.Data,
+		}
+		if r.Occurred != "" {
+			ms, err := r.Occurred.Int64()
+			if err != nil {
+				return nil, "", descope.ErrInvalidResponse.WithMessage("Invalid audit record time %s", r.Occurred).WithCause(err)
+			}
+			record.Occurred = time.UnixMilli(ms).UTC()
+		}
+		records = append(records, record)
+	}
+	return records, ares.NextCursor, nil
+}
+
+type auditIterator struct {
+	audit   *audit
+	options descope.AuditSearchOptions
+	cursor  string
+	page    []*descope.AuditRecord
+	index   int
+	current *descope.AuditRecord
+	done    bool
+	err     error
+}
+
+func (it *auditIterator) Next() bool {
+	for it.index >= len(it.page) {
+		if it.done {
+			it.current = nil
+			return false
+		}
+		page, cursor, err := it.audit.searchPage(&it.options, it.cursor)
+		if err != nil {
+			it.err = err
+			it.done = true
+			it.page = nil
+			it.current = nil
+			return false
+		}
+		// no continuation means this is the last page of results, and an empty page or a
+		// repeated cursor are treated the same to avoid requesting the same page forever
+		it.done = cursor == "" || cursor == it.cursor || len(page) == 0
+		it.cursor = cursor
+		it.page = page
+		it.index = 0
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+func (it *auditIterator) Record() *descope.AuditRecord {
+	return it.current
+}
+
+func (it *auditIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,198 @@
+package mgmt
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doAuditPages(t *testing.T, pages []map[string]any, cursors *[]any) func(r *http.Request) (*http.Response, error) {
+	return func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/audit/search"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		*cursors = append(*cursors, req["cursor"])
+		return helpers.DoOkWithBody(nil, pages[len(*cursors)-1])(r)
+	}
+}
+
+func TestAuditSearchSuccess(t *testing.T) {
+	from := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	options := &descope.AuditSearchOptions{
+		UserIDs:         []string{"u1"},
+		LoginIDs:        []string{"a@x.com"},
+		Tenants:         []string{"t1"},
+		Actions:         []string{"LoginSucceed"},
+		RemoteAddresses: []string{"10.0.0.1"},
+		From:            from,
+		To:              to,
+		Limit:           2,
+	}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, []any{"u1"}, req["userIds"])
+		require.Equal(t, []any{"a@x.com"}, req["loginIds"])
+		require.Equal(t, []any{"t1"}, req["tenants"])
+		require.Equal(t, []any{"LoginSucceed"}, req["actions"])
+		require.Equal(t, []any{"10.0.0.1"}, req["remoteAddresses"])
+		require.EqualValues(t, from.UnixMilli(), req["from"])
+		require.EqualValues(t, to.UnixMilli(), req["to"])
+		require.EqualValues(t, 2, req["limit"])
+		require.Nil(t, req["cursor"])
+	}, map[string]any{
+		"audits": []map[string]any{{
+			"projectId":     "p1",
+			"userId":        "u1",
+			"action":        "LoginSucceed",
+			"occurred":      "1693526400000",
+			"device":        "Desktop",
+			"method":        "otp",
+			"geo":           "US",
+			"remoteAddress": "10.0.0.1",
+			"externalIds":   []string{"a@x.com"},
+			"tenants":       []string{"t1"},
+			"data":          map[string]any{"x": "y"},
+		}},
+	}))
+	it := mgmt.Audit().Search(options)
+	require.True(t, it.Next())
+	assert.Equal(t, &descope.AuditRecord{
+		ProjectID:     "p1",
+		UserID:        "u1",
+		Action:        "LoginSucceed",
+		Occurred:      from,
+		Device:        "Desktop",
+		Method:        "otp",
+		Geo:           "US",
+		RemoteAddress: "10.0.0.1",
+		LoginIDs:      []string{"a@x.com"},
+		Tenants:       []string{"t1"},
+		Data:          map[string]any{"x": "y"},
+	}, it.Record())
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+	assert.Nil(t, it.Record())
+}
+
+func TestAuditSearchFollowsContinuation(t *testing.T) {
+	pages := []map[string]any{
+		{"audits": []map[string]any{{"action": "a1"}, {"action": "a2"}}, "nextCursor": "c1"},
+		{"audits": []map[string]any{{"action": "a3", "occurred": 1693526400000}}, "nextCursor": "c2"},
+		{"audits": []map[string]any{{"action": "a4"}}},
+	}
+	var cursors []any
+	mgmt := newTestMgmt(nil, doAuditPages(t, pages, &cursors))
+	it := mgmt.Audit().Search(nil)
+	actions := []string{}
+	for it.Next() {
+		actions = append(actions, it.Record().Action)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"a1", "a2", "a3", "a4"}, actions)
+	assert.Equal(t, []any{nil, "c1", "c2"}, cursors)
+	require.False(t, it.Next())
+}
+
+func TestAuditSearchStopsOnStuckContinuation(t *testing.T) {
+	pages := []map[string]any{
+		{"audits": []map[string]any{{"action": "a1"}}, "nextCursor": "c1"},
+		{"audits": []map[string]any{{"action": "a2"}}, "nextCursor": "c1"},
+		{"audits": []map[string]any{{"action": "a3"}}},
+	}
+	var cursors []any
+	mgmt := newTestMgmt(nil, doAuditPages(t, pages, &cursors))
+	it := mgmt.Audit().Search(nil)
+	actions := []string{}
+	for it.Next() {
+		actions = append(actions, it.Record().Action)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"a1", "a2"}, actions)
+	assert.Equal(t, []any{nil, "c1"}, cursors)
+
+	pages = []map[string]any{
+		{"audits": []map[string]any{}, "nextCursor": "c1"},
+		{"audits": []map[string]any{{"action": "a1"}}},
+	}
+	cursors = nil
+	mgmt = newTestMgmt(nil, doAuditPages(t, pages, &cursors))
+	it = mgmt.Audit().Search(nil)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+	assert.Equal(t, []any{nil}, cursors)
+}
+
+func TestAuditSearchError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	it := mgmt.Audit().Search(&descope.AuditSearchOptions{Limit: -1})
+	require.False(t, it.Next())
+	require.ErrorContains(t, it.Err(), utils.NewInvalidArgumentError("limit").Message)
+
+	now := time.Now()
+	it = mgmt.Audit().Search(&descope.AuditSearchOptions{From: now, To: now.Add(-time.Hour)})
+	require.False(t, it.Next())
+	require.ErrorContains(t, it.Err(), utils.NewInvalidArgumentError("to").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	it = mgmt.Audit().Search(nil)
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+	require.False(t, it.Next())
+
+	mgmt = newTestMgmt(nil, helpers.DoOkWithBody(nil, map[string]any{"audits": []map[string]any{{"action": "LoginSucceed", "occurred": 1.5}}}))
+	it = mgmt.Audit().Search(nil)
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), descope.ErrInvalidResponse)
+	require.ErrorContains(t, it.Err(), "Invalid audit record time 1.5")
+}
+
+func TestAuditExportSuccess(t *testing.T) {
+	pages := []map[string]any{
+		{"audits": []map[string]any{{"action": "a1", "userId": "u1", "occurred": "1693526400000"}}, "nextCursor": "c1"},
+		{"audits": []map[string]any{{"action": "a2", "tenants": []string{"t1"}, "occurred": "1693526400000"}}},
+	}
+	var cursors []any
+	mgmt := newTestMgmt(nil, doAuditPages(t, pages, &cursors))
+	out := &bytes.Buffer{}
+	count, err := mgmt.Audit().Export(out, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, `{"userId":"u1","action":"a1","occurred":"2023-09-01T00:00:00Z"}
+{"action":"a2","occurred":"2023-09-01T00:00:00Z","tenants":["t1"]}
+`, out.String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestAuditExportError(t *testing.T) {
+	pages := []map[string]any{
+		{"audits": []map[string]any{{"action": "a1"}}, "nextCursor": "c1"},
+	}
+	var cursors []any
+	mgmt := newTestMgmt(nil, doAuditPages(t, pages, &cursors))
+	count, err := mgmt.Audit().Export(failingWriter{}, nil)
+	require.ErrorContains(t, err, "write failed")
+	assert.Equal(t, 0, count)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	count, err = mgmt.Audit().Export(&bytes.Buffer{}, nil)
+	require.Error(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,80 @@
+package mgmt
+
+import (
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/api"
+	"github.com/descope/go-sdk/descope/internal/utils"
+)
+
+type flow struct {
+	managementBase
+}
+
+func (f *flow) ListFlows() ([]*descope.FlowMetadata, error) {
+	res, err := f.client.DoPostRequest(api.Routes.ManagementFlowList(), map[string]any{}, nil, f.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	fres := struct {
+		Flows []*descope.FlowMetadata `json:"flows"`
+	}{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &fres); err != nil {
+		return nil, err // notest
+	}
+	return fres.Flows, nil
+}
+
+func (f *flow) ExportFlow(flowID string) (map[string]any, error) {
+	if flowID == "" {
+		return nil, utils.NewInvalidArgumentError("flowID")
+	}
+	body := map[string]any{"flowId": flowID}
+	res, err := f.client.DoPostRequest(api.Routes.ManagementFlowExport(), body, nil, f.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	flowJSON := map[string]any{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &flowJSON); err != nil {
+		return nil, err // notest
+	}
+	return flowJSON, nil
+}
+
+func (f *flow) ImportFlow(flowID string, flowJSON map[string]any) error {
+	if flowID == "" {
+		return utils.NewInvalidArgumentError("flowID")
+	}
+	if len(flowJSON) == 0 {
+		return utils.NewInvalidArgumentError("flowJSON")
+	}
+	body := map[string]any{}
+	for k, v := range flowJSON {
+		body[k] = v
+	}
+	body["flowId"] = flowID
+	_, err := f.client.DoPostRequest(api.Routes.ManagementFlowImport(), body, nil, f.conf.ManagementKey)
+	return err
+}
+
+func (f *flow) ExportTheme() (map[string]any, error) {
+	res, err := f.client.DoPostRequest(api.Routes.ManagementThemeExport(), map[string]any{}, nil, f.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	tres := struct {
+		Theme map[string]any `json:"theme"`
+	}{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &tres); err != nil {
+		return nil, err // notest
+	}
+	return tres.Theme, nil
+}
+
+func (f *flow) ImportTheme(theme map[string]any) error {
+	if len(theme) == 0 {
+		return utils.NewInvalidArgumentError("theme")
+	}
+	body := map[string]any{"theme": theme}
+	_, err := f.client.DoPostRequest(api.Routes.ManagementThemeImport(), body, nil, f.conf.ManagementKey)
+	return err
+}
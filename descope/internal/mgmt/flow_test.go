@@ -0,0 +1,120 @@
+package mgmt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFlowsSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/flow/list"))
+	}, map[string]any{"flows": []map[string]any{{"id": "sign-in", "name": "Sign In", "disabled": true}}, "total": 1}))
+	res, err := mgmt.Flow().ListFlows()
+	require.NoError(t, err)
+	assert.Equal(t, []*descope.FlowMetadata{{ID: "sign-in", Name: "Sign In", Disabled: true}}, res)
+}
+
+func TestListFlowsError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := mgmt.Flow().ListFlows()
+	require.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestExportFlowSuccess(t *testing.T) {
+	response := map[string]any{"flow": map[string]any{"id": "sign-in"}, "screens": []any{map[string]any{"id": "s1"}}}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/flow/export"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "sign-in", req["flowId"])
+	}, response))
+	res, err := mgmt.Flow().ExportFlow("sign-in")
+	require.NoError(t, err)
+	assert.Equal(t, response, res)
+}
+
+func TestExportFlowError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := mgmt.Flow().ExportFlow("")
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("flowID").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	_, err = mgmt.Flow().ExportFlow("sign-in")
+	require.Error(t, err)
+}
+
+func TestImportFlowSuccess(t *testing.T) {
+	flowJSON := map[string]any{"flowId": "sign-in", "flow": map[string]any{"id": "sign-in"}, "screens": []any{}}
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/flow/import"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "sign-in-v2", req["flowId"])
+		require.Equal(t, map[string]any{"id": "sign-in"}, req["flow"])
+		require.Equal(t, []any{}, req["screens"])
+	}))
+	err := mgmt.Flow().ImportFlow("sign-in-v2", flowJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "sign-in", flowJSON["flowId"])
+}
+
+func TestImportFlowError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.Flow().ImportFlow("", map[string]any{"flow": nil})
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("flowID").Message)
+	err = mgmt.Flow().ImportFlow("sign-in", nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("flowJSON").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.Flow().ImportFlow("sign-in", map[string]any{"flow": nil})
+	require.Error(t, err)
+}
+
+func TestExportThemeSuccess(t *testing.T) {
+	theme := map[string]any{"id": "theme", "cssTemplate": map[string]any{"dark": map[string]any{}}}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/theme/export"))
+	}, map[string]any{"theme": theme}))
+	res, err := mgmt.Flow().ExportTheme()
+	require.NoError(t, err)
+	assert.Equal(t, theme, res)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	_, err = mgmt.Flow().ExportTheme()
+	require.Error(t, err)
+}
+
+func TestImportThemeSuccess(t *testing.T) {
+	theme := map[string]any{"id": "theme"}
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/theme/import"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, theme, req["theme"])
+	}))
+	err := mgmt.Flow().ImportTheme(theme)
+	require.NoError(t, err)
+}
+
+func TestImportThemeError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.Flow().ImportTheme(nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("theme").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.Flow().ImportTheme(map[string]any{"id": "theme"})
+	require.Error(t, err)
+}
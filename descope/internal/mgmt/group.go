@@ -61,6 +61,198 @@ func (r *group) LoadAllGroupMembers(tenantID, groupID string) ([]*descope.Group,
 	return unmarshalGroupsResponse(res)
 }
 
+func (r *group) Create(tenantID, groupID, display string, userIDs []string) error {
+	if tenantID == "" {
+		return utils.NewInvalidArgumentError("tenantID")
+	}
+	if groupID == "" {
+		return utils.NewInvalidArgumentError("groupID")
+	}
+	if display == "" {
+		return utils.NewInvalidArgumentError("display")
+	}
+	body := map[string]any{
+		"tenantId": tenantID,
+		"groupId":  groupID,
+		"display":  display,
+		"userIds":  userIDs,
+	}
+	_, err := r.client.DoPostRequest(api.Routes.ManagementGroupCreate(), body, nil, r.conf.ManagementKey)
+	return err
+}
+
+func (r *group) Update(tenantID, groupID, display string) error {
+	if tenantID == "" {
+		return utils.NewInvalidArgumentError("tenantID")
+	}
+	if groupID == "" {
+		return utils.NewInvalidArgumentError("groupID")
+	}
+	if display == "" {
+		return utils.NewInvalidArgumentError("display")
+	}
+	body := map[string]any{
+		"tenantId": tenantID,
+		"groupId":  groupID,
+		"display":  display,
+	}
+	_, err := r.client.DoPostRequest(api.Routes.ManagementGroupUpdate(), body, nil, r.conf.ManagementKey)
+	return err
+}
+
+func (r *group) Delete(tenantID, groupID string) error {
+	if tenantID == "" {
+		return utils.NewInvalidArgumentError("tenantID")
+	}
+	if groupID == "" {
+		return utils.NewInvalidArgumentError("groupID")
+	}
+	body := map[string]any{
+		"tenantId": tenantID,
+		"groupId":  groupID,
+	}
+	_, err := r.client.DoPostRequest(api.Routes.ManagementGroupDelete(), body, nil, r.conf.ManagementKey)
+	return err
+}
+
+func (r *group) AddMembers(tenantID, groupID string, userIDs, loginIDs []string) error {
+	return r.changeMembers(api.Routes.ManagementGroupAddMembers(), tenantID, groupID, userIDs, loginIDs)
+}
+
+func (r *group) RemoveMembers(tenantID, groupID string, userIDs, loginIDs []string) error {
+	return r.changeMembers(api.Routes.ManagementGroupRemoveMembers(), tenantID, groupID, userIDs, loginIDs)
+}
+
+func (r *group) changeMembers(route, tenantID, groupID string, userIDs, loginIDs []string) error {
+	if tenantID == "" {
+		return utils.NewInvalidArgumentError("tenantID")
+	}
+	if groupID == "" {
+		return utils.NewInvalidArgumentError("groupID")
+	}
+	if len(userIDs) == 0 && len(loginIDs) == 0 {
+		return utils.NewInvalidArgumentError("userIDs and loginIDs")
+	}
+	body := map[string]any{
+		"tenantId": tenantID,
+		"groupId":  groupID,
+		"userIds":  userIDs,
+		"loginIds": loginIDs,
+	}
+	_, err := r.client.DoPostRequest(route, body, nil, r.conf.ManagementKey)
+	return err
+}
+
+func (r *group) SyncGroups(tenantID string, desired []descope.Group) (*descope.GroupSyncResult, error) {
+	if tenantID == "" {
+		return nil, utils.NewInvalidArgumentError("tenantID")
+	}
+	wanted := map[string]bool{}
+	for i := range desired {
+		if desired[i].ID == "" || wanted[desired[i].ID] {
+			return nil, utils.NewInvalidArgumentError("desired")
+		}
+		wanted[desired[i].ID] = true
+	}
+
+	current, err := r.LoadAllGroups(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]*descope.Group{}
+	for _, g := range current {
+		existing[g.ID] = g
+	}
+
+	result := &descope.GroupSyncResult{}
+	for i := range desired {
+		g := &desired[i]
+		cur, ok := existing[g.ID]
+		if !ok {
+			cur = &descope.Group{ID: g.ID, Display: g.Display}
+			userIDs, _, _, _ := diffGroupMembers(nil, g.Members)
+			if err := r.Create(tenantID, g.ID, g.Display, userIDs); err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, g.ID)
+			for _, id := range userIDs {
+				cur.Members = append(cur.Members, descope.GroupMember{UserID: id})
+			}
+		} else if cur.Display != g.Display {
+			if err := r.Update(tenantID, g.ID, g.Display); err != nil {
+				return result, err
+			}
+			result.Updated = append(result.Updated, g.ID)
+		}
+
+		addUserIDs, addLoginIDs, removeUserIDs, removeLoginIDs := diffGroupMembers(cur.Members, g.Members)
+		if len(addUserIDs) > 0 || len(addLoginIDs) > 0 {
+			if err := r.AddMembers(tenantID, g.ID, addUserIDs, addLoginIDs); err != nil {
+				return result, err
+			}
+		}
+		if len(removeUserIDs) > 0 || len(removeLoginIDs) > 0 {
+			if err := r.RemoveMembers(tenantID, g.ID, removeUserIDs, removeLoginIDs); err != nil {
+				return result, err
+			}
+		}
+		if ok && len(addUserIDs)+len(addLoginIDs)+len(removeUserIDs)+len(removeLoginIDs) > 0 {
+			result.MembersChanged = append(result.MembersChanged, g.ID)
+		}
+	}
+
+	for _, g := range current {
+		if wanted[g.ID] {
+			continue
+		}
+		if err := r.Delete(tenantID, g.ID); err != nil {
+			return result, err
+		}
+		result.Deleted = append(result.Deleted, g.ID)
+	}
+	return result, nil
+}
+
+// Returns the members that need to be added to or removed from a group for its current
+// members to match the desired ones. Desired members are identified by their UserID, or
+// by their LoginID when they don't have one.
+func diffGroupMembers(current, desired []descope.GroupMember) (addUserIDs, addLoginIDs, removeUserIDs, removeLoginIDs []string) {
+	currentUsers, currentLogins := map[string]bool{}, map[string]bool{}
+	for _, m := range current {
+		if m.UserID != "" {
+			currentUsers[m.UserID] = true
+		}
+		if m.LoginID != "" {
+			currentLogins[m.LoginID] = true
+		}
+	}
+	desiredUsers, desiredLogins := map[string]bool{}, map[string]bool{}
+	for _, m := range desired {
+		if m.UserID != "" {
+			if !desiredUsers[m.UserID] && !currentUsers[m.UserID] {
+				addUserIDs = append(addUserIDs, m.UserID)
+			}
+			desiredUsers[m.UserID] = true
+		} else if m.LoginID != "" {
+			if !desiredLogins[m.LoginID] && !currentLogins[m.LoginID] {
+				addLoginIDs = append(addLoginIDs, m.LoginID)
+			}
+			desiredLogins[m.LoginID] = true
+		}
+	}
+	for _, m := range current {
+		if (m.UserID != "" && desiredUsers[m.UserID]) || (m.LoginID != "" && desiredLogins[m.LoginID]) {
+			continue
+		}
+		if m.UserID != "" {
+			removeUserIDs = append(removeUserIDs, m.UserID)
+		} else {
+			removeLoginIDs = append(removeLoginIDs, m.LoginID)
+		}
+	}
+	return addUserIDs, addLoginIDs, removeUserIDs, removeLoginIDs
+}
+
 func unmarshalGroupsResponse(res *api.HTTPResponse) ([]*descope.Group, error) {
 	var groups []*descope.Group
 	err := utils.Unmarshal([]byte(res.BodyStr), &groups)
@@ -1,7 +1,9 @@
 package mgmt
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/descope/go-sdk/descope"
@@ -165,3 +167,198 @@ func TestLoadAllGroupMembersError(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, res)
 }
+
+func TestGroupCreateSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/group/create"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "t1", req["tenantId"])
+		require.Equal(t, "g1", req["groupId"])
+		require.Equal(t, "Engineering", req["display"])
+		require.Equal(t, []any{"u1"}, req["userIds"])
+	}))
+	err := mgmt.Group().Create("t1", "g1", "Engineering", []string{"u1"})
+	require.NoError(t, err)
+}
+
+func TestGroupCreateError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.Group().Create("", "g1", "Engineering", nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("tenantID").Message)
+	err = mgmt.Group().Create("t1", "", "Engineering", nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("groupID").Message)
+	err = mgmt.Group().Create("t1", "g1", "", nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("display").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.Group().Create("t1", "g1", "Engineering", nil)
+	require.Error(t, err)
+}
+
+func TestGroupUpdateSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/group/update"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "t1", req["tenantId"])
+		require.Equal(t, "g1", req["groupId"])
+		require.Equal(t, "Engineering", req["display"])
+	}))
+	err := mgmt.Group().Update("t1", "g1", "Engineering")
+	require.NoError(t, err)
+}
+
+func TestGroupUpdateError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.Group().Update("t1", "g1", "")
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("display").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.Group().Update("t1", "g1", "Engineering")
+	require.Error(t, err)
+}
+
+func TestGroupDeleteSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/group/delete"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "t1", req["tenantId"])
+		require.Equal(t, "g1", req["groupId"])
+	}))
+	err := mgmt.Group().Delete("t1", "g1")
+	require.NoError(t, err)
+}
+
+func TestGroupDeleteError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.Group().Delete("t1", "")
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("groupID").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.Group().Delete("t1", "g1")
+	require.Error(t, err)
+}
+
+func TestGroupAddAndRemoveMembersSuccess(t *testing.T) {
+	var path string
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		path = r.URL.Path
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "t1", req["tenantId"])
+		require.Equal(t, "g1", req["groupId"])
+		require.Equal(t, []any{"u1"}, req["userIds"])
+		require.Equal(t, []any{"l1"}, req["loginIds"])
+	}))
+	err := mgmt.Group().AddMembers("t1", "g1", []string{"u1"}, []string{"l1"})
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(path, "mgmt/group/members/add"))
+	err = mgmt.Group().RemoveMembers("t1", "g1", []string{"u1"}, []string{"l1"})
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(path, "mgmt/group/members/remove"))
+}
+
+func TestGroupAddAndRemoveMembersError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.Group().AddMembers("t1", "g1", nil, nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("userIDs and loginIDs").Message)
+	err = mgmt.Group().RemoveMembers("", "g1", []string{"u1"}, nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("tenantID").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.Group().AddMembers("t1", "g1", []string{"u1"}, nil)
+	require.Error(t, err)
+	err = mgmt.Group().RemoveMembers("t1", "g1", []string{"u1"}, nil)
+	require.Error(t, err)
+}
+
+func doSyncGroups(t *testing.T, current []*descope.Group, calls *[]string, failPath string) func(r *http.Request) (*http.Response, error) {
+	return func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "mgmt/group/all") {
+			return helpers.DoOkWithBody(nil, current)(r)
+		}
+		if failPath != "" && strings.HasSuffix(r.URL.Path, failPath) {
+			return helpers.DoBadRequest(nil)(r)
+		}
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		call := strings.TrimPrefix(r.URL.Path, "/v1/mgmt/group/") + " " + req["groupId"].(string)
+		for _, key := range []string{"display", "userIds", "loginIds"} {
+			if v, ok := req[key]; ok && v != nil {
+				call += fmt.Sprintf(" %s=%v", key, v)
+			}
+		}
+		*calls = append(*calls, call)
+		return helpers.DoOk(nil)(r)
+	}
+}
+
+func TestSyncGroupsSuccess(t *testing.T) {
+	current := []*descope.Group{
+		{ID: "eng", Display: "Engineering", Members: []descope.GroupMember{{UserID: "u1", LoginID: "a@x.com"}, {UserID: "u2", LoginID: "b@x.com"}}},
+		{ID: "ops", Display: "Ops", Members: []descope.GroupMember{{UserID: "u3", LoginID: "c@x.com"}}},
+		{ID: "old", Display: "Old"},
+	}
+	desired := []descope.Group{
+		{ID: "eng", Display: "Engineering", Members: []descope.GroupMember{{UserID: "u1"}, {LoginID: "d@x.com"}}},
+		{ID: "ops", Display: "Operations", Members: []descope.GroupMember{{LoginID: "c@x.com"}}},
+		{ID: "sales", Display: "Sales", Members: []descope.GroupMember{{UserID: "u4"}, {LoginID: "e@x.com"}}},
+	}
+	var calls []string
+	mgmt := newTestMgmt(nil, doSyncGroups(t, current, &calls, ""))
+	res, err := mgmt.Group().SyncGroups("t1", desired)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"members/add eng loginIds=[d@x.com]",
+		"members/remove eng userIds=[u2]",
+		"update ops display=Operations",
+		"create sales display=Sales userIds=[u4]",
+		"members/add sales loginIds=[e@x.com]",
+		"delete old",
+	}, calls)
+	assert.Equal(t, &descope.GroupSyncResult{
+		Created:        []string{"sales"},
+		Updated:        []string{"ops"},
+		Deleted:        []string{"old"},
+		MembersChanged: []string{"eng"},
+	}, res)
+}
+
+func TestSyncGroupsNoChanges(t *testing.T) {
+	current := []*descope.Group{{ID: "eng", Display: "Engineering", Members: []descope.GroupMember{{UserID: "u1", LoginID: "a@x.com"}}}}
+	desired := []descope.Group{{ID: "eng", Display: "Engineering", Members: []descope.GroupMember{{LoginID: "a@x.com"}}}}
+	var calls []string
+	mgmt := newTestMgmt(nil, doSyncGroups(t, current, &calls, ""))
+	res, err := mgmt.Group().SyncGroups("t1", desired)
+	require.NoError(t, err)
+	assert.Empty(t, calls)
+	assert.Equal(t, &descope.GroupSyncResult{}, res)
+}
+
+func TestSyncGroupsError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := mgmt.Group().SyncGroups("", nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("tenantID").Message)
+	_, err = mgmt.Group().SyncGroups("t1", []descope.Group{{Display: "No ID"}})
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("desired").Message)
+	_, err = mgmt.Group().SyncGroups("t1", []descope.Group{{ID: "eng", Display: "A"}, {ID: "eng", Display: "B"}})
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("desired").Message)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	_, err = mgmt.Group().SyncGroups("t1", nil)
+	require.Error(t, err)
+
+	current := []*descope.Group{{ID: "old", Display: "Old"}}
+	var calls []string
+	mgmt = newTestMgmt(nil, doSyncGroups(t, current, &calls, "mgmt/group/delete"))
+	res, err := mgmt.Group().SyncGroups("t1", []descope.Group{{ID: "eng", Display: "Engineering"}})
+	require.Error(t, err)
+	assert.Equal(t, []string{"eng"}, res.Created)
+	assert.Empty(t, res.Deleted)
+}
@@ -28,6 +28,9 @@ type managementService struct {
 	permission sdk.Permission
 	role       sdk.Role
 	group      sdk.Group
+	audit      sdk.Audit
+	project    sdk.Project
+	flow       sdk.FlowManagement
 }
 
 func NewManagement(conf ManagementParams, c *api.Client) *managementService {
@@ -41,6 +44,9 @@ func NewManagement(conf ManagementParams, c *api.Client) *managementService {
 	service.permission = &permission{managementBase: base}
 	service.role = &role{managementBase: base}
 	service.group = &group{managementBase: base}
+	service.audit = &audit{managementBase: base}
+	service.project = &project{managementBase: base, mgmt: service}
+	service.flow = &flow{managementBase: base}
 	return service
 }
 
@@ -84,6 +90,21 @@ func (mgmt *managementService) Group() sdk.Group {
 	return mgmt.group
 }
 
+func (mgmt *managementService) Audit() sdk.Audit {
+	mgmt.ensureManagementKey()
+	return mgmt.audit
+}
+
+func (mgmt *managementService) Project() sdk.Project {
+	mgmt.ensureManagementKey()
+	return mgmt.project
+}
+
+func (mgmt *managementService) Flow() sdk.FlowManagement {
+	mgmt.ensureManagementKey()
+	return mgmt.flow
+}
+
 func (mgmt *managementService) ensureManagementKey() {
 	if mgmt.conf.ManagementKey == "" {
 		logger.LogInfo("Management key is missing, make sure to add it in the Config struct or the environment variable \"%s\"", descope.EnvironmentVariableManagementKey) // notest
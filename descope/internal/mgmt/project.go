@@ -0,0 +1,301 @@
+package mgmt
+
+import (
+	"sort"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/config"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/sdk"
+)
+
+type project struct {
+	managementBase
+	mgmt sdk.Management
+}
+
+func (p *project) Export() (*descope.ProjectSnapshot, error) {
+	// an empty section means the section is managed, so all its entities are loaded
+	current, err := config.Current(p.mgmt, &config.Project{Permissions: []*descope.Permission{}, Roles: []*descope.Role{}, Tenants: []*descope.Tenant{}})
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &descope.ProjectSnapshot{
+		Version:     descope.ProjectSnapshotVersion,
+		Permissions: current.Permissions,
+		Roles:       current.Roles,
+		Tenants:     current.Tenants,
+	}
+	for _, tenant := range current.Tenants {
+		settings, err := p.mgmt.SSO().LoadSettings(tenant.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !ssoConfigured(settings) {
+			continue
+		}
+		// the tenant details are already in the tenants section
+		settings.Tenant = &descope.Tenant{ID: tenant.ID}
+		snapshot.SSOSettings = append(snapshot.SSOSettings, settings)
+	}
+	flows, err := p.mgmt.Flow().ListFlows()
+	if err != nil {
+		return nil, err
+	}
+	for _, flow := range flows {
+		flowJSON, err := p.mgmt.Flow().ExportFlow(flow.ID)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.Flows == nil {
+			snapshot.Flows = map[string]any{}
+		}
+		snapshot.Flows[flow.ID] = flowJSON
+	}
+	normalizeProjectSnapshot(snapshot)
+	return snapshot, nil
+}
+
+func (p *project) Import(snapshot *descope.ProjectSnapshot, options *descope.ProjectImportOptions) ([]*descope.ProjectImportChange, error) {
+	if snapshot == nil {
+		return nil, utils.NewInvalidArgumentError("snapshot")
+	}
+	if snapshot.Version < 1 || snapshot.Version > descope.ProjectSnapshotVersion {
+		return nil, descope.ErrInvalidArguments.WithMessage("Unsupported snapshot version %d", snapshot.Version)
+	}
+	if options == nil {
+		options = &descope.ProjectImportOptions{}
+	}
+	for _, settings := range snapshot.SSOSettings {
+		if ssoSettingsTenantID(settings) == "" {
+			return nil, descope.ErrValidationFailure.WithMessage("SSO settings are missing a tenant id")
+		}
+	}
+
+	// the authorization model is imported the same way a configuration file is applied
+	desired := &config.Project{Permissions: snapshot.Permissions, Roles: snapshot.Roles, Tenants: snapshot.Tenants}
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+	plan, err := config.NewPlan(p.mgmt, desired)
+	if err != nil {
+		return nil, err
+	}
+	if !options.Prune {
+		plan = withoutDeletes(plan)
+	}
+	created := map[string]bool{}
+	for _, change := range plan.Changes {
+		if change.Resource == config.ResourceTenant && change.Action == config.ActionCreate {
+			created[change.Tenant.ID] = true
+		}
+	}
+	changes := []*descope.ProjectImportChange{}
+	applied, err := config.Apply(p.mgmt, plan, &config.ApplyOptions{DryRun: options.DryRun})
+	for _, change := range applied {
+		changes = append(changes, &descope.ProjectImportChange{Entity: string(change.Resource), ID: change.Key(), Action: string(change.Action)})
+	}
+	if err != nil {
+		return changes, err
+	}
+
+	for _, settings := range snapshot.SSOSettings {
+		change, err := p.importSSOSettings(settings, created[ssoSettingsTenantID(settings)], options.DryRun)
+		if err != nil {
+			return changes, err
+		}
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	if len(snapshot.Flows) > 0 {
+		flowChanges, err := p.importFlows(snapshot.Flows, options.DryRun)
+		changes = append(changes, flowChanges...)
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+// Configures the SSO settings of a tenant if they're different from its current settings. An empty
+// OIDC client secret is left out of the request, so the tenant keeps its current client secret.
+func (p *project) importSSOSettings(settings *descope.SSOSettingsResponse, newTenant, dryRun bool) (*descope.ProjectImportChange, error) {
+	tenantID := ssoSettingsTenantID(settings)
+	change := &descope.ProjectImportChange{Entity: "sso", ID: tenantID, Action: "create"}
+	// a tenant created by this import has no settings yet, and in a dry run it doesn't even exist
+	if !newTenant {
+		current, err := p.mgmt.SSO().LoadSettings(tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if sameSSOSettings(current, settings) {
+			return nil, nil
+		}
+		if ssoConfigured(current) {
+			change.Action = "update"
+		}
+	}
+	if dryRun {
+		return change, nil
+	}
+
+	var err error
+	sso := p.mgmt.SSO()
+	switch {
+	case settings.OIDC != nil:
+		err = sso.ConfigureOIDCSettings(tenantID, settings.OIDC)
+	case settings.IdpMetadataURL != "":
+		err = sso.ConfigureMetadata(tenantID, settings.IdpMetadataURL)
+	case settings.IdpURL != "":
+		err = sso.ConfigureSettings(tenantID, settings.IdpURL, settings.IdpCertificate, settings.IdpEntityID, settings.RedirectURL)
+	}
+	if err == nil && (len(settings.RoleMappings) > 0 || settings.AttributeMapping != nil) {
+		err = sso.ConfigureMapping(tenantID, settings.RoleMappings, settings.AttributeMapping)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// Imports the flows that are different from the project's current flows, ordered by their IDs
+func (p *project) importFlows(flows map[string]any, dryRun bool) ([]*descope.ProjectImportChange, error) {
+	existing, err := p.mgmt.Flow().ListFlows()
+	if err != nil {
+		return nil, err
+	}
+	exists := map[string]bool{}
+	for _, flow := range existing {
+		exists[flow.ID] = true
+	}
+	ids := make([]string, 0, len(flows))
+	for id := range flows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	changes := []*descope.ProjectImportChange{}
+	for _, id := range ids {
+		flowJSON, ok := flows[id].(map[string]any)
+		if !ok {
+			return changes, descope.ErrValidationFailure.WithMessage("Flow %s is not a JSON object", id)
+		}
+		change := &descope.ProjectImportChange{Entity: "flow", ID: id, Action: "create"}
+		if exists[id] {
+			current, err := p.mgmt.Flow().ExportFlow(id)
+			if err != nil {
+				return changes, err
+			}
+			if sameFlow(current, flowJSON) {
+				continue
+			}
+			change.Action = "update"
+		}
+		if !dryRun {
+			if err := p.mgmt.Flow().ImportFlow(id, flowJSON); err != nil {
+				return changes, err
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// Returns a copy of the plan without its delete changes, so entities that are only in
+// the target project are kept
+func withoutDeletes(plan *config.Plan) *config.Plan {
+	result := &config.Plan{}
+	for _, change := range plan.Changes {
+		if change.Action != config.ActionDelete {
+			result.Changes = append(result.Changes, change)
+		}
+	}
+	return result
+}
+
+func ssoConfigured(settings *descope.SSOSettingsResponse) bool {
+	return settings != nil && (settings.OIDC != nil || settings.IdpURL != "" || settings.IdpMetadataURL != "")
+}
+
+func sameSSOSettings(current, desired *descope.SSOSettingsResponse) bool {
+	if current == nil {
+		return false
+	}
+	// secrets aren't exported and the tenant details might differ between projects
+	a, b := *current, *desired
+	a.Tenant, b.Tenant = nil, nil
+	if a.OIDC != nil {
+		oidc := *a.OIDC
+		oidc.ClientSecret = ""
+		a.OIDC = &oidc
+	}
+	if b.OIDC != nil {
+		oidc := *b.OIDC
+		oidc.ClientSecret = ""
+		b.OIDC = &oidc
+	}
+	return sameJSON(&a, &b)
+}
+
+func sameFlow(current, desired map[string]any) bool {
+	a, b := map[string]any{}, map[string]any{}
+	for k, v := range current {
+		a[k] = v
+	}
+	for k, v := range desired {
+		b[k] = v
+	}
+	delete(a, "flowId")
+	delete(b, "flowId")
+	return sameJSON(a, b)
+}
+
+// Compares values by their JSON representation, in which map keys are always sorted
+func sameJSON(a, b any) bool {
+	ab, err := utils.Marshal(a)
+	if err != nil {
+		return false // notest
+	}
+	bb, err := utils.Marshal(b)
+	if err != nil {
+		return false // notest
+	}
+	return string(ab) == string(bb)
+}
+
+// Sorts the entities in the snapshot so that it's serialized deterministically, and
+// removes any secrets from it.
+func normalizeProjectSnapshot(snapshot *descope.ProjectSnapshot) {
+	sort.Slice(snapshot.Permissions, func(i, j int) bool {
+		return snapshot.Permissions[i].Name < snapshot.Permissions[j].Name
+	})
+	sort.Slice(snapshot.Roles, func(i, j int) bool {
+		return snapshot.Roles[i].Name < snapshot.Roles[j].Name
+	})
+	for _, role := range snapshot.Roles {
+		sort.Strings(role.PermissionNames)
+	}
+	sort.Slice(snapshot.Tenants, func(i, j int) bool {
+		return snapshot.Tenants[i].ID < snapshot.Tenants[j].ID
+	})
+	for _, tenant := range snapshot.Tenants {
+		sort.Strings(tenant.SelfProvisioningDomains)
+	}
+	sort.Slice(snapshot.SSOSettings, func(i, j int) bool {
+		return ssoSettingsTenantID(snapshot.SSOSettings[i]) < ssoSettingsTenantID(snapshot.SSOSettings[j])
+	})
+	for _, settings := range snapshot.SSOSettings {
+		if settings.OIDC != nil {
+			settings.OIDC.ClientSecret = ""
+		}
+	}
+}
+
+func ssoSettingsTenantID(settings *descope.SSOSettingsResponse) string {
+	if settings.Tenant == nil {
+		return ""
+	}
+	return settings.Tenant.ID
+}
@@ -0,0 +1,202 @@
+package mgmt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectExportSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		switch strings.TrimPrefix(r.URL.Path, "/v1/") {
+		case "mgmt/permission/all":
+			return helpers.DoOkWithBody(nil, map[string]any{"permissions": []map[string]any{{"name": "write"}, {"name": "read"}}})(r)
+		case "mgmt/role/all":
+			return helpers.DoOkWithBody(nil, map[string]any{"roles": []map[string]any{{"name": "viewer", "permissionNames": []string{"read"}}, {"name": "editor", "permissionNames": []string{"write", "read"}}}})(r)
+		case "mgmt/tenant/all":
+			return helpers.DoOkWithBody(nil, map[string]any{"tenants": []map[string]any{{"id": "t3", "name": "Three"}, {"id": "t2", "name": "Two", "selfProvisioningDomains": []string{"b.com", "a.com"}}, {"id": "t1", "name": "One"}}})(r)
+		case "mgmt/sso/settings":
+			switch r.URL.Query().Get("tenantId") {
+			case "t1":
+				return helpers.DoOkWithBody(nil, map[string]any{"tenant": map[string]any{"id": "t1", "name": "One"}, "idpSSOUrl": "https://idp"})(r)
+			case "t2":
+				return helpers.DoOkWithBody(nil, map[string]any{"tenant": map[string]any{"id": "t2"}, "oidc": map[string]any{"clientId": "c", "clientSecret": "s"}})(r)
+			}
+			return helpers.DoOkWithBody(nil, map[string]any{"tenant": map[string]any{"id": "t3"}})(r)
+		case "mgmt/flow/list":
+			return helpers.DoOkWithBody(nil, map[string]any{"flows": []map[string]any{{"id": "sign-up"}}})(r)
+		case "mgmt/flow/export":
+			return helpers.DoOkWithBody(nil, map[string]any{"flowId": "sign-up", "name": "Sign Up"})(r)
+		}
+		require.Fail(t, "unexpected request", r.URL.Path)
+		return nil, nil
+	})
+	snapshot, err := mgmt.Project().Export()
+	require.NoError(t, err)
+	assert.Equal(t, descope.ProjectSnapshotVersion, snapshot.Version)
+	assert.Equal(t, []*descope.Permission{{Name: "read"}, {Name: "write"}}, snapshot.Permissions)
+	assert.Equal(t, []*descope.Role{{Name: "editor", PermissionNames: []string{"read", "write"}}, {Name: "viewer", PermissionNames: []string{"read"}}}, snapshot.Roles)
+	require.Len(t, snapshot.Tenants, 3)
+	assert.Equal(t, "t1", snapshot.Tenants[0].ID)
+	assert.Equal(t, []string{"a.com", "b.com"}, snapshot.Tenants[1].SelfProvisioningDomains)
+	// tenants without SSO settings are skipped
+	require.Len(t, snapshot.SSOSettings, 2)
+	assert.Equal(t, &descope.Tenant{ID: "t1"}, snapshot.SSOSettings[0].Tenant)
+	assert.Equal(t, "https://idp", snapshot.SSOSettings[0].IdpURL)
+	assert.Equal(t, "c", snapshot.SSOSettings[1].OIDC.ClientID)
+	assert.Empty(t, snapshot.SSOSettings[1].OIDC.ClientSecret)
+	assert.Equal(t, map[string]any{"sign-up": map[string]any{"flowId": "sign-up", "name": "Sign Up"}}, snapshot.Flows)
+}
+
+func TestProjectExportError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoBadRequest(nil))
+	snapshot, err := mgmt.Project().Export()
+	require.Error(t, err)
+	assert.Nil(t, snapshot)
+
+	mgmt = newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "mgmt/sso/settings") {
+			return helpers.DoBadRequest(nil)(r)
+		}
+		return helpers.DoOkWithBody(nil, map[string]any{"tenants": []map[string]any{{"id": "t1", "name": "One"}}})(r)
+	})
+	snapshot, err = mgmt.Project().Export()
+	require.Error(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func doProjectImport(t *testing.T, requests *[]string) func(r *http.Request) (*http.Response, error) {
+	return func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		req := map[string]any{}
+		if r.Method == http.MethodPost {
+			require.NoError(t, helpers.ReadBody(r, &req))
+		}
+		switch path {
+		case "mgmt/permission/all":
+			return helpers.DoOkWithBody(nil, map[string]any{"permissions": []map[string]any{{"name": "read"}, {"name": "old"}}})(r)
+		case "mgmt/role/all":
+			return helpers.DoOkWithBody(nil, map[string]any{"roles": []map[string]any{{"name": "viewer", "permissionNames": []string{"read"}}}})(r)
+		case "mgmt/tenant/all":
+			return helpers.DoOkWithBody(nil, map[string]any{"tenants": []map[string]any{{"id": "t1", "name": "One"}}})(r)
+		case "mgmt/sso/settings":
+			// the settings of tenants created by the import aren't loaded
+			require.Equal(t, "t1", r.URL.Query().Get("tenantId"))
+			return helpers.DoOkWithBody(nil, map[string]any{"tenant": map[string]any{"id": "t1"}, "oidc": map[string]any{"name": "Okta", "issuer": "https://okta", "clientId": "c"}})(r)
+		case "mgmt/sso/oidc":
+			settings := req["settings"].(map[string]any)
+			// the secret isn't exported, so it's left out to keep the current one
+			require.NotContains(t, settings, "clientSecret")
+		case "mgmt/flow/list":
+			return helpers.DoOkWithBody(nil, map[string]any{"flows": []map[string]any{{"id": "sign-in"}, {"id": "sign-up"}}})(r)
+		case "mgmt/flow/export":
+			return helpers.DoOkWithBody(nil, map[string]any{"flowId": req["flowId"], "name": req["flowId"]})(r)
+		}
+		*requests = append(*requests, path)
+		return helpers.DoOk(nil)(r)
+	}
+}
+
+func TestProjectImportSuccess(t *testing.T) {
+	snapshot := &descope.ProjectSnapshot{
+		Version:     descope.ProjectSnapshotVersion,
+		Permissions: []*descope.Permission{{Name: "read"}, {Name: "write"}},
+		Roles:       []*descope.Role{{Name: "viewer", PermissionNames: []string{"read"}}, {Name: "editor", PermissionNames: []string{"read", "write"}}},
+		Tenants:     []*descope.Tenant{{ID: "t1", Name: "One"}, {ID: "t2", Name: "Two"}},
+		SSOSettings: []*descope.SSOSettingsResponse{
+			{Tenant: &descope.Tenant{ID: "t1"}, OIDC: &descope.OIDCSettings{Name: "Okta", Issuer: "https://okta", ClientID: "c"}},
+			{Tenant: &descope.Tenant{ID: "t2"}, OIDC: &descope.OIDCSettings{Name: "Okta", Issuer: "https://okta", ClientID: "c2"}},
+		},
+		Flows: map[string]any{
+			"sign-up": map[string]any{"name": "sign-up"},
+			"sign-in": map[string]any{"name": "Sign In"},
+			"step-up": map[string]any{"name": "Step Up"},
+		},
+	}
+	expected := []string{
+		"create permission write",
+		"create role editor",
+		"create tenant t2",
+		"create sso t2",
+		"update flow sign-in",
+		"create flow step-up",
+	}
+
+	requests := []string{}
+	mgmt := newTestMgmt(nil, doProjectImport(t, &requests))
+	changes, err := mgmt.Project().Import(snapshot, &descope.ProjectImportOptions{DryRun: true})
+	require.NoError(t, err)
+	actual := []string{}
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	assert.Equal(t, expected, actual)
+	assert.Empty(t, requests)
+
+	changes, err = mgmt.Project().Import(snapshot, nil)
+	require.NoError(t, err)
+	assert.Len(t, changes, len(expected))
+	assert.Equal(t, []string{
+		"mgmt/permission/create",
+		"mgmt/role/create",
+		"mgmt/tenant/create",
+		"mgmt/sso/oidc",
+		"mgmt/flow/import",
+		"mgmt/flow/import",
+	}, requests)
+}
+
+func TestProjectImportPrune(t *testing.T) {
+	snapshot := &descope.ProjectSnapshot{
+		Version:     descope.ProjectSnapshotVersion,
+		Permissions: []*descope.Permission{{Name: "read"}},
+		Tenants:     []*descope.Tenant{},
+	}
+	requests := []string{}
+	mgmt := newTestMgmt(nil, doProjectImport(t, &requests))
+	changes, err := mgmt.Project().Import(snapshot, &descope.ProjectImportOptions{Prune: true})
+	require.NoError(t, err)
+	actual := []string{}
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	assert.Equal(t, []string{"delete permission old", "delete tenant t1"}, actual)
+	assert.Equal(t, []string{"mgmt/permission/delete", "mgmt/tenant/delete"}, requests)
+
+	requests = []string{}
+	changes, err = mgmt.Project().Import(snapshot, nil)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, requests)
+}
+
+func TestProjectImportError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := mgmt.Project().Import(nil, nil)
+	require.ErrorContains(t, err, utils.NewInvalidArgumentError("snapshot").Message)
+	_, err = mgmt.Project().Import(&descope.ProjectSnapshot{Version: descope.ProjectSnapshotVersion + 1}, nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	require.ErrorContains(t, err, "Unsupported snapshot version")
+	_, err = mgmt.Project().Import(&descope.ProjectSnapshot{Version: descope.ProjectSnapshotVersion, Roles: []*descope.Role{{}}}, nil)
+	require.ErrorIs(t, err, descope.ErrValidationFailure)
+	_, err = mgmt.Project().Import(&descope.ProjectSnapshot{Version: descope.ProjectSnapshotVersion, SSOSettings: []*descope.SSOSettingsResponse{{}}}, nil)
+	require.ErrorIs(t, err, descope.ErrValidationFailure)
+	_, err = mgmt.Project().Import(&descope.ProjectSnapshot{Version: descope.ProjectSnapshotVersion, Flows: map[string]any{"f": "flow"}}, nil)
+	require.ErrorIs(t, err, descope.ErrValidationFailure)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	_, err = mgmt.Project().Import(&descope.ProjectSnapshot{Version: descope.ProjectSnapshotVersion, Roles: []*descope.Role{}}, nil)
+	require.Error(t, err)
+	_, err = mgmt.Project().Import(&descope.ProjectSnapshot{Version: descope.ProjectSnapshotVersion, SSOSettings: []*descope.SSOSettingsResponse{{Tenant: &descope.Tenant{ID: "t1"}}}}, nil)
+	require.Error(t, err)
+	_, err = mgmt.Project().Import(&descope.ProjectSnapshot{Version: descope.ProjectSnapshotVersion, Flows: map[string]any{"f": map[string]any{}}}, nil)
+	require.Error(t, err)
+}
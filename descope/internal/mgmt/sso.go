@@ -4,6 +4,7 @@ import (
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/api"
 	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/logger"
 )
 
 type sso struct {
@@ -68,3 +69,74 @@ func (s *sso) ConfigureMapping(tenantID string, roleMappings []*descope.RoleMapp
 	_, err := s.client.DoPostRequest(api.Routes.ManagementSSOMapping(), req, nil, s.conf.ManagementKey)
 	return err
 }
+
+func (s *sso) ConfigureOIDCSettings(tenantID string, settings *descope.OIDCSettings) error {
+	if tenantID == "" {
+		return utils.NewInvalidArgumentError("tenantID")
+	}
+	if settings == nil {
+		return utils.NewInvalidArgumentError("settings")
+	}
+	if settings.ClientID == "" {
+		return utils.NewInvalidArgumentError("settings.ClientID")
+	}
+	if settings.Issuer == "" && settings.DiscoveryURL == "" {
+		return utils.NewInvalidArgumentError("settings.Issuer")
+	}
+	req := map[string]any{
+		"tenantId": tenantID,
+		"settings": settings,
+	}
+	_, err := s.client.DoPostRequest(api.Routes.ManagementSSOOIDCConfigure(), req, nil, s.conf.ManagementKey)
+	return err
+}
+
+func (s *sso) LoadSettings(tenantID string) (*descope.SSOSettingsResponse, error) {
+	if tenantID == "" {
+		return nil, utils.NewInvalidArgumentError("tenantID")
+	}
+	req := &api.HTTPRequest{
+		QueryParams: map[string]string{"tenantId": tenantID},
+	}
+	res, err := s.client.DoGetRequest(api.Routes.ManagementSSOLoadSettings(), req, s.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalSSOSettingsResponse(res)
+}
+
+func (s *sso) DeleteSettings(tenantID string) error {
+	if tenantID == "" {
+		return utils.NewInvalidArgumentError("tenantID")
+	}
+	req := &api.HTTPRequest{
+		QueryParams: map[string]string{"tenantId": tenantID},
+	}
+	_, err := s.client.DoDeleteRequest(api.Routes.ManagementSSODeleteSettings(), req, s.conf.ManagementKey)
+	return err
+}
+
+func unmarshalSSOSettingsResponse(res *api.HTTPResponse) (*descope.SSOSettingsResponse, error) {
+	sres := struct {
+		*descope.SSOSettingsResponse
+		RoleMappings []struct {
+			Groups   []string `json:"groups"`
+			RoleName string   `json:"roleName"`
+		} `json:"roleMappings"`
+	}{SSOSettingsResponse: &descope.SSOSettingsResponse{}}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &sres); err != nil {
+		return nil, err // notest
+	}
+	settings := sres.SSOSettingsResponse
+	for _, mapping := range sres.RoleMappings {
+		settings.RoleMappings = append(settings.RoleMappings, &descope.RoleMapping{Groups: mapping.Groups, Role: mapping.RoleName})
+	}
+	if settings.IdpCertificate != "" {
+		if cert, err := descope.ParseIdPCertificate(settings.IdpCertificate); err == nil {
+			settings.IdpCertificateExpiry = cert.NotAfter
+		} else {
+			logger.LogDebug("Failed to parse the IdP certificate in the SSO settings: %s", err.Error())
+		}
+	}
+	return settings, nil
+}
@@ -1,8 +1,16 @@
 package mgmt
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/tests/helpers"
@@ -87,3 +95,126 @@ func TestSSOConfigureMappingError(t *testing.T) {
 	err := mgmt.SSO().ConfigureMapping("", nil, nil)
 	require.Error(t, err)
 }
+
+func TestSSOConfigureOIDCSettingsSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/sso/oidc"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["tenantId"])
+		settings, ok := req["settings"].(map[string]any)
+		require.True(t, ok)
+		require.Equal(t, "Okta", settings["name"])
+		require.Equal(t, "https://acme.okta.com", settings["issuer"])
+		require.Equal(t, "client", settings["clientId"])
+		require.Equal(t, "secret", settings["clientSecret"])
+		require.EqualValues(t, []any{"openid", "email"}, settings["scopes"])
+		require.Equal(t, "https://acme.okta.com/.well-known/openid-configuration", settings["discoveryUrl"])
+		require.Equal(t, map[string]any{"email": "email", "groups": "groups"}, settings["attributeMapping"])
+	}))
+	err := mgmt.SSO().ConfigureOIDCSettings("abc", &descope.OIDCSettings{
+		Name:             "Okta",
+		Issuer:           "https://acme.okta.com",
+		ClientID:         "client",
+		ClientSecret:     "secret",
+		Scopes:           []string{"openid", "email"},
+		DiscoveryURL:     "https://acme.okta.com/.well-known/openid-configuration",
+		AttributeMapping: &descope.OIDCAttributeMapping{Email: "email", Group: "groups"},
+	})
+	require.NoError(t, err)
+}
+
+func TestSSOConfigureOIDCSettingsError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.SSO().ConfigureOIDCSettings("", &descope.OIDCSettings{ClientID: "client", Issuer: "issuer"})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	err = mgmt.SSO().ConfigureOIDCSettings("abc", nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	err = mgmt.SSO().ConfigureOIDCSettings("abc", &descope.OIDCSettings{Issuer: "issuer"})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	err = mgmt.SSO().ConfigureOIDCSettings("abc", &descope.OIDCSettings{ClientID: "client"})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.SSO().ConfigureOIDCSettings("abc", &descope.OIDCSettings{ClientID: "client", DiscoveryURL: "https://idp"})
+	require.Error(t, err)
+}
+
+func TestSSOLoadSettingsSuccess(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	notAfter := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: notAfter}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	response := map[string]any{
+		"tenant":           map[string]any{"id": "abc", "name": "Acme"},
+		"idpSSOUrl":        "http://idpURL",
+		"idpEntityId":      "entity",
+		"idpCertificate":   cert,
+		"roleMappings":     []map[string]any{{"groups": []string{"admins"}, "roleName": "Tenant Admin"}},
+		"attributeMapping": map[string]any{"email": "mail"},
+	}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.Equal(t, http.MethodGet, r.Method)
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/sso/settings"))
+		require.Equal(t, "abc", r.URL.Query().Get("tenantId"))
+	}, response))
+	res, err := mgmt.SSO().LoadSettings("abc")
+	require.NoError(t, err)
+	require.Equal(t, "Acme", res.Tenant.Name)
+	require.Equal(t, "http://idpURL", res.IdpURL)
+	require.Equal(t, "entity", res.IdpEntityID)
+	require.Equal(t, cert, res.IdpCertificate)
+	require.Equal(t, notAfter, res.IdpCertificateExpiry)
+	require.Len(t, res.RoleMappings, 1)
+	require.EqualValues(t, []string{"admins"}, res.RoleMappings[0].Groups)
+	require.Equal(t, "Tenant Admin", res.RoleMappings[0].Role)
+	require.Equal(t, "mail", res.AttributeMapping.Email)
+	require.Nil(t, res.OIDC)
+}
+
+func TestSSOLoadSettingsInvalidCertificate(t *testing.T) {
+	response := map[string]any{"idpCertificate": "invalid", "oidc": map[string]any{"clientId": "client"}}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(nil, response))
+	res, err := mgmt.SSO().LoadSettings("abc")
+	require.NoError(t, err)
+	require.True(t, res.IdpCertificateExpiry.IsZero())
+	require.Equal(t, "client", res.OIDC.ClientID)
+}
+
+func TestSSOLoadSettingsError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := mgmt.SSO().LoadSettings("")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := mgmt.SSO().LoadSettings("abc")
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestSSODeleteSettingsSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/sso/settings"))
+		require.Equal(t, "abc", r.URL.Query().Get("tenantId"))
+	}))
+	err := mgmt.SSO().DeleteSettings("abc")
+	require.NoError(t, err)
+}
+
+func TestSSODeleteSettingsError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.SSO().DeleteSettings("")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.SSO().DeleteSettings("abc")
+	require.Error(t, err)
+}
@@ -11,22 +11,29 @@ type tenant struct {
 }
 
 func (t *tenant) Create(name string, selfProvisioningDomains []string) (id string, err error) {
-	return t.createWithID("", name, selfProvisioningDomains)
+	return t.createWithID("", name, selfProvisioningDomains, nil)
 }
 
 func (t *tenant) CreateWithID(id, name string, selfProvisioningDomains []string) error {
 	if id == "" {
 		return utils.NewInvalidArgumentError("id")
 	}
-	_, err := t.createWithID(id, name, selfProvisioningDomains)
+	_, err := t.createWithID(id, name, selfProvisioningDomains, nil)
 	return err
 }
 
-func (t *tenant) createWithID(id, name string, selfProvisioningDomains []string) (string, error) {
+func (t *tenant) CreateWithOptions(id string, tenant *descope.TenantRequest) (string, error) {
+	if tenant == nil {
+		return "", utils.NewInvalidArgumentError("tenant")
+	}
+	return t.createWithID(id, tenant.Name, tenant.SelfProvisioningDomains, tenant.CustomAttributes)
+}
+
+func (t *tenant) createWithID(id, name string, selfProvisioningDomains []string, customAttributes map[string]any) (string, error) {
 	if name == "" {
 		return "", utils.NewInvalidArgumentError("name")
 	}
-	req := makeCreateUpdateTenantRequest(id, name, selfProvisioningDomains)
+	req := makeCreateUpdateTenantRequest(id, name, selfProvisioningDomains, customAttributes)
 	httpRes, err := t.client.DoPostRequest(api.Routes.ManagementTenantCreate(), req, nil, t.conf.ManagementKey)
 	if err != nil {
 		return "", err
@@ -37,17 +44,31 @@ func (t *tenant) createWithID(id, name string, selfProvisioningDomains []string)
 	if err = utils.Unmarshal([]byte(httpRes.BodyStr), res); err != nil {
 		return "", err
 	}
+	if res.ID == "" {
+		return id, nil
+	}
 	return res.ID, nil
 }
 
 func (t *tenant) Update(id, name string, selfProvisioningDomains []string) error {
+	return t.update(id, name, selfProvisioningDomains, nil)
+}
+
+func (t *tenant) UpdateWithOptions(id string, tenant *descope.TenantRequest) error {
+	if tenant == nil {
+		return utils.NewInvalidArgumentError("tenant")
+	}
+	return t.update(id, tenant.Name, tenant.SelfProvisioningDomains, tenant.CustomAttributes)
+}
+
+func (t *tenant) update(id, name string, selfProvisioningDomains []string, customAttributes map[string]any) error {
 	if id == "" {
 		return utils.NewInvalidArgumentError("id")
 	}
 	if name == "" {
 		return utils.NewInvalidArgumentError("name")
 	}
-	req := makeCreateUpdateTenantRequest(id, name, selfProvisioningDomains)
+	req := makeCreateUpdateTenantRequest(id, name, selfProvisioningDomains, customAttributes)
 	_, err := t.client.DoPostRequest(api.Routes.ManagementTenantUpdate(), req, nil, t.conf.ManagementKey)
 	return err
 }
@@ -61,6 +82,24 @@ func (t *tenant) Delete(id string) error {
 	return err
 }
 
+func (t *tenant) Load(id string) (*descope.Tenant, error) {
+	if id == "" {
+		return nil, utils.NewInvalidArgumentError("id")
+	}
+	req := &api.HTTPRequest{
+		QueryParams: map[string]string{"id": id},
+	}
+	res, err := t.client.DoGetRequest(api.Routes.ManagementTenantLoad(), req, t.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	tenant := &descope.Tenant{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), tenant); err != nil {
+		return nil, err // notest
+	}
+	return tenant, nil
+}
+
 func (t *tenant) LoadAll() ([]*descope.Tenant, error) {
 	res, err := t.client.DoGetRequest(api.Routes.ManagementTenantLoadAll(), nil, t.conf.ManagementKey)
 	if err != nil {
@@ -69,8 +108,107 @@ func (t *tenant) LoadAll() ([]*descope.Tenant, error) {
 	return unmarshalLoadAllTenantsResponse(res)
 }
 
-func makeCreateUpdateTenantRequest(id, name string, selfProvisioningDomains []string) map[string]any {
-	return map[string]any{"id": id, "name": name, "selfProvisioningDomains": selfProvisioningDomains}
+func (t *tenant) SearchAll(options *descope.TenantSearchOptions) ([]*descope.Tenant, error) {
+	req := makeSearchAllTenantsRequest(options)
+	res, err := t.client.DoPostRequest(api.Routes.ManagementTenantSearchAll(), req, nil, t.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalLoadAllTenantsResponse(res)
+}
+
+func (t *tenant) GetSettings(id string) (*descope.TenantSettings, error) {
+	if id == "" {
+		return nil, utils.NewInvalidArgumentError("id")
+	}
+	req := &api.HTTPRequest{
+		QueryParams: map[string]string{"id": id},
+	}
+	res, err := t.client.DoGetRequest(api.Routes.ManagementTenantSettings(), req, t.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	settings := &descope.TenantSettings{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), settings); err != nil {
+		return nil, err // notest
+	}
+	return settings, nil
+}
+
+func (t *tenant) ConfigureSettings(id string, settings *descope.TenantSettings) error {
+	if id == "" {
+		return utils.NewInvalidArgumentError("id")
+	}
+	if settings == nil {
+		return utils.NewInvalidArgumentError("settings")
+	}
+	req := makeTenantSettingsRequest(id, settings)
+	_, err := t.client.DoPostRequest(api.Routes.ManagementTenantSettings(), req, nil, t.conf.ManagementKey)
+	return err
+}
+
+// Only the settings that are set are sent, so the server leaves the others as they are
+func makeTenantSettingsRequest(id string, settings *descope.TenantSettings) map[string]any {
+	req := map[string]any{"tenantId": id}
+	if settings.SessionSettingsEnabled != nil {
+		req["sessionSettingsEnabled"] = *settings.SessionSettingsEnabled
+	}
+	if settings.SessionTokenExpiration != nil {
+		req["sessionTokenExpiration"] = *settings.SessionTokenExpiration
+	}
+	if settings.SessionTokenExpirationUnit != nil {
+		req["sessionTokenExpirationUnit"] = *settings.SessionTokenExpirationUnit
+	}
+	if settings.RefreshTokenExpiration != nil {
+		req["refreshTokenExpiration"] = *settings.RefreshTokenExpiration
+	}
+	if settings.RefreshTokenExpirationUnit != nil {
+		req["refreshTokenExpirationUnit"] = *settings.RefreshTokenExpirationUnit
+	}
+	if settings.EnableInactivity != nil {
+		req["enableInactivity"] = *settings.EnableInactivity
+	}
+	if settings.InactivityTime != nil {
+		req["inactivityTime"] = *settings.InactivityTime
+	}
+	if settings.InactivityTimeUnit != nil {
+		req["inactivityTimeUnit"] = *settings.InactivityTimeUnit
+	}
+	if settings.AuthMethods != nil {
+		req["authMethods"] = settings.AuthMethods
+	}
+	if settings.EnforceDomains != nil {
+		req["enforceDomains"] = *settings.EnforceDomains
+	}
+	return req
+}
+
+func makeCreateUpdateTenantRequest(id, name string, selfProvisioningDomains []string, customAttributes map[string]any) map[string]any {
+	req := map[string]any{"id": id, "name": name, "selfProvisioningDomains": selfProvisioningDomains}
+	if customAttributes != nil {
+		req["customAttributes"] = customAttributes
+	}
+	return req
+}
+
+func makeSearchAllTenantsRequest(options *descope.TenantSearchOptions) map[string]any {
+	req := map[string]any{}
+	if options == nil {
+		return req
+	}
+	if len(options.IDs) > 0 {
+		req["tenantIds"] = options.IDs
+	}
+	if len(options.Names) > 0 {
+		req["tenantNames"] = options.Names
+	}
+	if len(options.SelfProvisioningDomains) > 0 {
+		req["tenantSelfProvisioningDomains"] = options.SelfProvisioningDomains
+	}
+	if len(options.CustomAttributes) > 0 {
+		req["customAttributes"] = options.CustomAttributes
+	}
+	return req
 }
 
 func unmarshalLoadAllTenantsResponse(res *api.HTTPResponse) ([]*descope.Tenant, error) {
@@ -2,8 +2,10 @@ package mgmt
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/tests/helpers"
 	"github.com/stretchr/testify/require"
 )
@@ -125,3 +127,194 @@ func TestTenantLoadError(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, res)
 }
+
+func TestTenantCreateWithCustomAttributes(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, map[string]any{"plan": "pro"}, req["customAttributes"])
+	}, map[string]any{"id": "qux"}))
+	id, err := mgmt.Tenant().CreateWithOptions("", &descope.TenantRequest{Name: "abc", CustomAttributes: map[string]any{"plan": "pro"}})
+	require.NoError(t, err)
+	require.Equal(t, "qux", id)
+
+	mgmt = newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "123", req["id"])
+		require.NotContains(t, req, "customAttributes")
+	}))
+	id, err = mgmt.Tenant().CreateWithOptions("123", &descope.TenantRequest{Name: "abc"})
+	require.NoError(t, err)
+	require.Equal(t, "123", id)
+	_, err = mgmt.Tenant().CreateWithOptions("123", nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestTenantUpdateWithCustomAttributes(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, map[string]any{"plan": "pro"}, req["customAttributes"])
+	}))
+	err := mgmt.Tenant().UpdateWithOptions("123", &descope.TenantRequest{Name: "abc", CustomAttributes: map[string]any{"plan": "pro"}})
+	require.NoError(t, err)
+
+	mgmt = newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.NotContains(t, req, "customAttributes")
+	}))
+	err = mgmt.Tenant().Update("123", "abc", nil)
+	require.NoError(t, err)
+	err = mgmt.Tenant().UpdateWithOptions("123", &descope.TenantRequest{Name: "abc"})
+	require.NoError(t, err)
+	err = mgmt.Tenant().UpdateWithOptions("123", nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestTenantLoadByIDSuccess(t *testing.T) {
+	response := map[string]any{
+		"id":                      "t1",
+		"name":                    "abc",
+		"selfProvisioningDomains": []string{"domain.com"},
+		"customAttributes":        map[string]any{"plan": "pro"},
+	}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.Equal(t, http.MethodGet, r.Method)
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/tenant"))
+		require.Equal(t, "t1", r.URL.Query().Get("id"))
+	}, response))
+	res, err := mgmt.Tenant().Load("t1")
+	require.NoError(t, err)
+	require.Equal(t, "t1", res.ID)
+	require.Equal(t, "abc", res.Name)
+	plan, ok := res.CustomAttributes.String("plan")
+	require.True(t, ok)
+	require.Equal(t, "pro", plan)
+}
+
+func TestTenantLoadByIDError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := mgmt.Tenant().Load("")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := mgmt.Tenant().Load("t1")
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestTenantSearchAllSuccess(t *testing.T) {
+	response := map[string]any{"tenants": []map[string]any{{"id": "t1", "name": "abc"}}}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/tenant/search"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.EqualValues(t, []any{"t1"}, req["tenantIds"])
+		require.EqualValues(t, []any{"abc"}, req["tenantNames"])
+		require.EqualValues(t, []any{"domain.com"}, req["tenantSelfProvisioningDomains"])
+		require.EqualValues(t, map[string]any{"plan": "pro"}, req["customAttributes"])
+	}, response))
+	res, err := mgmt.Tenant().SearchAll(&descope.TenantSearchOptions{
+		IDs:                     []string{"t1"},
+		Names:                   []string{"abc"},
+		SelfProvisioningDomains: []string{"domain.com"},
+		CustomAttributes:        map[string]any{"plan": "pro"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "t1", res[0].ID)
+
+	mgmt = newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Empty(t, req)
+	}, response))
+	res, err = mgmt.Tenant().SearchAll(nil)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+}
+
+func TestTenantSearchAllError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := mgmt.Tenant().SearchAll(nil)
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestTenantGetSettingsSuccess(t *testing.T) {
+	response := map[string]any{
+		"sessionSettingsEnabled":     true,
+		"refreshTokenExpiration":     7,
+		"refreshTokenExpirationUnit": "days",
+		"authMethods":                []string{"sso"},
+		"enforceDomains":             true,
+	}
+	mgmt := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.Equal(t, http.MethodGet, r.Method)
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/tenant/settings"))
+		require.Equal(t, "t1", r.URL.Query().Get("id"))
+	}, response))
+	res, err := mgmt.Tenant().GetSettings("t1")
+	require.NoError(t, err)
+	require.Equal(t, descope.Ptr(true), res.SessionSettingsEnabled)
+	require.Equal(t, descope.Ptr(7), res.RefreshTokenExpiration)
+	require.Equal(t, descope.Ptr("days"), res.RefreshTokenExpirationUnit)
+	require.EqualValues(t, []string{"sso"}, res.AuthMethods)
+	require.Equal(t, descope.Ptr(true), res.EnforceDomains)
+	require.Nil(t, res.SessionTokenExpiration)
+}
+
+func TestTenantGetSettingsError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := mgmt.Tenant().GetSettings("")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := mgmt.Tenant().GetSettings("t1")
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestTenantConfigureSettingsSuccess(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.Equal(t, http.MethodPost, r.Method)
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/tenant/settings"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "t1", req["tenantId"])
+		require.Equal(t, true, req["enableInactivity"])
+		require.EqualValues(t, 30, req["inactivityTime"])
+		require.Equal(t, "minutes", req["inactivityTimeUnit"])
+		require.EqualValues(t, []any{"otp", "sso"}, req["authMethods"])
+		require.Equal(t, false, req["enforceDomains"])
+		require.NotContains(t, req, "sessionSettingsEnabled")
+		require.NotContains(t, req, "sessionTokenExpiration")
+		require.NotContains(t, req, "refreshTokenExpirationUnit")
+	}))
+	err := mgmt.Tenant().ConfigureSettings("t1", &descope.TenantSettings{
+		EnableInactivity:   descope.Ptr(true),
+		InactivityTime:     descope.Ptr(30),
+		InactivityTimeUnit: descope.Ptr("minutes"),
+		AuthMethods:        []string{"otp", "sso"},
+		EnforceDomains:     descope.Ptr(false),
+	})
+	require.NoError(t, err)
+}
+
+func TestTenantConfigureSettingsError(t *testing.T) {
+	mgmt := newTestMgmt(nil, helpers.DoOk(nil))
+	err := mgmt.Tenant().ConfigureSettings("", &descope.TenantSettings{})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	err = mgmt.Tenant().ConfigureSettings("t1", nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	mgmt = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = mgmt.Tenant().ConfigureSettings("t1", &descope.TenantSettings{})
+	require.Error(t, err)
+}
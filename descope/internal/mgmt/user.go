@@ -4,17 +4,25 @@ import (
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/api"
 	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/sdk"
 )
 
+// The page size used by the search iterator when no limit is set in the options
+const defaultUserSearchPageSize = 100
+
 type user struct {
 	managementBase
 }
 
 func (u *user) Create(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant) (*descope.UserResponse, error) {
+	return u.CreateWithCustomAttributes(loginID, email, phone, displayName, roles, tenants, nil)
+}
+
+func (u *user) CreateWithCustomAttributes(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any) (*descope.UserResponse, error) {
 	if loginID == "" {
 		return nil, utils.NewInvalidArgumentError("loginID")
 	}
-	req := makeCreateUpdateUserRequest(loginID, email, phone, displayName, roles, tenants)
+	req := makeCreateUpdateUserRequest(loginID, email, phone, displayName, roles, tenants, customAttributes)
 	res, err := u.client.DoPostRequest(api.Routes.ManagementUserCreate(), req, nil, u.conf.ManagementKey)
 	if err != nil {
 		return nil, err
@@ -23,10 +31,14 @@ func (u *user) Create(loginID, email, phone, displayName string, roles []string,
 }
 
 func (u *user) Update(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant) (*descope.UserResponse, error) {
+	return u.UpdateWithCustomAttributes(loginID, email, phone, displayName, roles, tenants, nil)
+}
+
+func (u *user) UpdateWithCustomAttributes(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any) (*descope.UserResponse, error) {
 	if loginID == "" {
 		return nil, utils.NewInvalidArgumentError("loginID")
 	}
-	req := makeCreateUpdateUserRequest(loginID, email, phone, displayName, roles, tenants)
+	req := makeCreateUpdateUserRequest(loginID, email, phone, displayName, roles, tenants, customAttributes)
 	res, err := u.client.DoPostRequest(api.Routes.ManagementUserUpdate(), req, nil, u.conf.ManagementKey)
 	if err != nil {
 		return nil, err
@@ -34,6 +46,126 @@ func (u *user) Update(loginID, email, phone, displayName string, roles []string,
 	return unmarshalUserResponse(res)
 }
 
+func (u *user) CreateWithOptions(loginID string, user *descope.UserRequest) (*descope.UserResponse, error) {
+	if loginID == "" {
+		return nil, utils.NewInvalidArgumentError("loginID")
+	}
+	req := makeUserRequest(loginID, user)
+	res, err := u.client.DoPostRequest(api.Routes.ManagementUserCreate(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalUserResponse(res)
+}
+
+func (u *user) Patch(loginID string, user *descope.UserRequest) (*descope.UserResponse, error) {
+	if loginID == "" {
+		return nil, utils.NewInvalidArgumentError("loginID")
+	}
+	if user == nil {
+		return nil, utils.NewInvalidArgumentError("user")
+	}
+	req := makeUserRequest(loginID, user)
+	res, err := u.client.DoPostRequest(api.Routes.ManagementUserPatch(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalUserResponse(res)
+}
+
+func (u *user) CreateTestUser(loginID string, user *descope.UserRequest) (*descope.UserResponse, error) {
+	if loginID == "" {
+		return nil, utils.NewInvalidArgumentError("loginID")
+	}
+	req := makeUserRequest(loginID, user)
+	res, err := u.client.DoPostRequest(api.Routes.ManagementUserCreateTestUser(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalUserResponse(res)
+}
+
+func (u *user) GenerateOTPForTestUser(method descope.DeliveryMethod, loginID string) (string, error) {
+	if loginID == "" {
+		return "", utils.NewInvalidArgumentError("loginID")
+	}
+	req := map[string]any{"loginId": loginID, "deliveryMethod": method}
+	res, err := u.client.DoPostRequest(api.Routes.ManagementGenerateOTPForTestUser(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return "", err
+	}
+	tres := struct {
+		Code string `json:"code"`
+	}{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &tres); err != nil {
+		return "", err // notest
+	}
+	return tres.Code, nil
+}
+
+func (u *user) GenerateMagicLinkForTestUser(method descope.DeliveryMethod, loginID, URI string) (string, error) {
+	if loginID == "" {
+		return "", utils.NewInvalidArgumentError("loginID")
+	}
+	req := map[string]any{"loginId": loginID, "deliveryMethod": method, "URI": URI}
+	res, err := u.client.DoPostRequest(api.Routes.ManagementGenerateMagicLinkForTestUser(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return "", err
+	}
+	tres := struct {
+		Link string `json:"link"`
+	}{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &tres); err != nil {
+		return "", err // notest
+	}
+	return tres.Link, nil
+}
+
+func (u *user) GenerateEnchantedLinkForTestUser(loginID, URI string) (string, string, error) {
+	if loginID == "" {
+		return "", "", utils.NewInvalidArgumentError("loginID")
+	}
+	req := map[string]any{"loginId": loginID, "URI": URI}
+	res, err := u.client.DoPostRequest(api.Routes.ManagementGenerateEnchantedLinkForTestUser(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return "", "", err
+	}
+	tres := struct {
+		Link       string `json:"link"`
+		PendingRef string `json:"pendingRef"`
+	}{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &tres); err != nil {
+		return "", "", err // notest
+	}
+	return tres.Link, tres.PendingRef, nil
+}
+
+func (u *user) Impersonate(impersonatorID, loginID string, validateConsent bool) (string, error) {
+	if impersonatorID == "" {
+		return "", utils.NewInvalidArgumentError("impersonatorID")
+	}
+	if loginID == "" {
+		return "", utils.NewInvalidArgumentError("loginID")
+	}
+	req := map[string]any{"impersonatorId": impersonatorID, "loginId": loginID, "validateConsent": validateConsent}
+	res, err := u.client.DoPostRequest(api.Routes.ManagementUserImpersonate(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return "", err
+	}
+	ires := struct {
+		JWT string `json:"jwt"`
+	}{}
+	if err := utils.Unmarshal([]byte(res.BodyStr), &ires); err != nil {
+		return "", err // notest
+	}
+	return ires.JWT, nil
+}
+
+func (u *user) DeleteAllTestUsers() error {
+	_, err := u.client.DoDeleteRequest(api.Routes.ManagementUserDeleteAllTestUsers(), nil, u.conf.ManagementKey)
+	return err
+}
+
 func (u *user) Delete(loginID string) error {
 	if loginID == "" {
 		return utils.NewInvalidArgumentError("loginID")
@@ -69,7 +201,20 @@ func (u *user) load(loginID, userID string) (*descope.UserResponse, error) {
 }
 
 func (u *user) SearchAll(tenantIDs, roles []string, limit int32) ([]*descope.UserResponse, error) {
-	req := makeSearchAllRequest(tenantIDs, roles, limit)
+	return u.SearchAllWithOptions(&descope.UserSearchOptions{TenantIDs: tenantIDs, Roles: roles, Limit: limit})
+}
+
+func (u *user) SearchAllWithOptions(options *descope.UserSearchOptions) ([]*descope.UserResponse, error) {
+	if options == nil {
+		options = &descope.UserSearchOptions{}
+	}
+	if options.Page < 0 {
+		return nil, utils.NewInvalidArgumentError("page")
+	}
+	if options.Limit < 0 {
+		return nil, utils.NewInvalidArgumentError("limit")
+	}
+	req := makeSearchAllRequest(options)
 	res, err := u.client.DoPostRequest(api.Routes.ManagementUserSearchAll(), req, nil, u.conf.ManagementKey)
 	if err != nil {
 		return nil, err
@@ -77,6 +222,17 @@ func (u *user) SearchAll(tenantIDs, roles []string, limit int32) ([]*descope.Use
 	return unmarshalUserSearchAllResponse(res)
 }
 
+func (u *user) SearchIterator(options *descope.UserSearchOptions) sdk.UserIterator {
+	it := &userIterator{user: u, options: descope.UserSearchOptions{}}
+	if options != nil {
+		it.options = *options
+	}
+	if it.options.Limit == 0 {
+		it.options.Limit = defaultUserSearchPageSize
+	}
+	return it
+}
+
 func (u *user) Activate(loginID string) (*descope.UserResponse, error) {
 	return u.updateStatus(loginID, "enabled")
 }
@@ -133,6 +289,21 @@ func (u *user) UpdateDisplayName(loginID, displayName string) (*descope.UserResp
 	return unmarshalUserResponse(res)
 }
 
+func (u *user) UpdateCustomAttribute(loginID, key string, value any) (*descope.UserResponse, error) {
+	if loginID == "" {
+		return nil, utils.NewInvalidArgumentError("loginID")
+	}
+	if key == "" {
+		return nil, utils.NewInvalidArgumentError("key")
+	}
+	req := map[string]any{"loginId": loginID, "attributeKey": key, "attributeValue": value}
+	res, err := u.client.DoPostRequest(api.Routes.ManagementUserUpdateCustomAttribute(), req, nil, u.conf.ManagementKey)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalUserResponse(res)
+}
+
 func (u *user) AddRoles(loginID string, roles []string) (*descope.UserResponse, error) {
 	if loginID == "" {
 		return nil, utils.NewInvalidArgumentError("loginID")
@@ -205,8 +376,29 @@ func (u *user) RemoveTenantRoles(loginID string, tenantID string, roles []string
 	return unmarshalUserResponse(res)
 }
 
-func makeCreateUpdateUserRequest(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant) map[string]any {
-	return map[string]any{
+func (u *user) SetPassword(loginID string, password string) error {
+	if loginID == "" {
+		return utils.NewInvalidArgumentError("loginID")
+	}
+	if password == "" {
+		return utils.NewInvalidArgumentError("password")
+	}
+	req := map[string]any{"loginId": loginID, "password": password}
+	_, err := u.client.DoPostRequest(api.Routes.ManagementUserSetPassword(), req, nil, u.conf.ManagementKey)
+	return err
+}
+
+func (u *user) ExpirePassword(loginID string) error {
+	if loginID == "" {
+		return utils.NewInvalidArgumentError("loginID")
+	}
+	req := map[string]any{"loginId": loginID}
+	_, err := u.client.DoPostRequest(api.Routes.ManagementUserExpirePassword(), req, nil, u.conf.ManagementKey)
+	return err
+}
+
+func makeCreateUpdateUserRequest(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any) map[string]any {
+	req := map[string]any{
 		"loginId":     loginID,
 		"email":       email,
 		"phone":       phone,
@@ -214,6 +406,51 @@ func makeCreateUpdateUserRequest(loginID, email, phone, displayName string, role
 		"roleNames":   roles,
 		"userTenants": makeAssociatedTenantList(tenants),
 	}
+	if customAttributes != nil {
+		req["customAttributes"] = customAttributes
+	}
+	return req
+}
+
+func makeUserRequest(loginID string, user *descope.UserRequest) map[string]any {
+	req := map[string]any{"loginId": loginID}
+	if user == nil {
+		return req
+	}
+	if user.Email != nil {
+		req["email"] = *user.Email
+	}
+	if user.Phone != nil {
+		req["phone"] = *user.Phone
+	}
+	if user.DisplayName != nil {
+		req["displayName"] = *user.DisplayName
+	}
+	if user.Picture != nil {
+		req["picture"] = *user.Picture
+	}
+	if user.VerifiedEmail != nil {
+		req["verifiedEmail"] = *user.VerifiedEmail
+	}
+	if user.VerifiedPhone != nil {
+		req["verifiedPhone"] = *user.VerifiedPhone
+	}
+	if user.Status != nil {
+		req["status"] = *user.Status
+	}
+	if user.Roles != nil {
+		req["roleNames"] = user.Roles
+	}
+	if user.Tenants != nil {
+		req["userTenants"] = makeAssociatedTenantList(user.Tenants)
+	}
+	if user.CustomAttributes != nil {
+		req["customAttributes"] = user.CustomAttributes
+	}
+	if user.AdditionalLoginIDs != nil {
+		req["additionalLoginIds"] = user.AdditionalLoginIDs
+	}
+	return req
 }
 
 func makeUpdateUserTenantRequest(loginID, tenantID string) map[string]any {
@@ -231,12 +468,35 @@ func makeUpdateUserRolesRequest(loginID, tenantID string, roles []string) map[st
 	}
 }
 
-func makeSearchAllRequest(tenantIDs, roles []string, limit int32) map[string]any {
-	return map[string]any{
-		"tenantIds": tenantIDs,
-		"roleNames": roles,
-		"limit":     limit,
+func makeSearchAllRequest(options *descope.UserSearchOptions) map[string]any {
+	req := map[string]any{
+		"tenantIds": options.TenantIDs,
+		"roleNames": options.Roles,
+		"limit":     options.Limit,
+		"page":      options.Page,
+	}
+	if len(options.LoginIDs) > 0 {
+		req["loginIds"] = options.LoginIDs
+	}
+	if len(options.Emails) > 0 {
+		req["emails"] = options.Emails
+	}
+	if len(options.Phones) > 0 {
+		req["phones"] = options.Phones
+	}
+	if options.Text != "" {
+		req["text"] = options.Text
+	}
+	if len(options.Statuses) > 0 {
+		req["statuses"] = options.Statuses
+	}
+	if len(options.CustomAttributes) > 0 {
+		req["customAttributes"] = options.CustomAttributes
+	}
+	if len(options.Sort) > 0 {
+		req["sort"] = options.Sort
 	}
+	return req
 }
 
 func unmarshalUserResponse(res *api.HTTPResponse) (*descope.UserResponse, error) {
@@ -260,3 +520,46 @@ func unmarshalUserSearchAllResponse(res *api.HTTPResponse) ([]*descope.UserRespo
 	}
 	return ures.Users, err
 }
+
+type userIterator struct {
+	user    *user
+	options descope.UserSearchOptions
+	page    []*descope.UserResponse
+	index   int
+	current *descope.UserResponse
+	done    bool
+	err     error
+}
+
+func (it *userIterator) Next() bool {
+	for it.index >= len(it.page) {
+		if it.done {
+			it.current = nil
+			return false
+		}
+		page, err := it.user.SearchAllWithOptions(&it.options)
+		if err != nil {
+			it.err = err
+			it.done = true
+			it.page = nil
+			it.current = nil
+			return false
+		}
+		// a page with fewer users than the limit is the last one
+		it.done = len(page) < int(it.options.Limit)
+		it.options.Page++
+		it.page = page
+		it.index = 0
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+func (it *userIterator) User() *descope.UserResponse {
+	return it.current
+}
+
+func (it *userIterator) Err() error {
+	return it.err
+}
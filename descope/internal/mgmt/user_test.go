@@ -2,6 +2,7 @@ package mgmt
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/descope/go-sdk/descope"
@@ -23,8 +24,9 @@ func TestUserCreateSuccess(t *testing.T) {
 		roleNames := req["roleNames"].([]any)
 		require.Len(t, roleNames, 1)
 		require.Equal(t, "foo", roleNames[0])
+		require.Equal(t, map[string]any{"department": "R&D", "employeeId": float64(42)}, req["customAttributes"])
 	}, response))
-	res, err := m.User().Create("abc", "foo@bar.com", "", "", []string{"foo"}, nil)
+	res, err := m.User().CreateWithCustomAttributes("abc", "foo@bar.com", "", "", []string{"foo"}, nil, map[string]any{"department": "R&D", "employeeId": 42})
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, "a@b.c", res.Email)
@@ -61,6 +63,7 @@ func TestUserUpdateSuccess(t *testing.T) {
 				require.Equal(t, "bar", roleNames[0])
 			}
 		}
+		require.NotContains(t, req, "customAttributes")
 	}, response))
 	res, err := m.User().Update("abc", "foo@bar.com", "", "", nil, []*descope.AssociatedTenant{{TenantID: "x", Roles: []string{"foo"}}, {TenantID: "y", Roles: []string{"bar"}}})
 	require.NoError(t, err)
@@ -68,10 +71,299 @@ func TestUserUpdateSuccess(t *testing.T) {
 	require.Equal(t, "a@b.c", res.Email)
 }
 
+func TestUserUpdateWithCustomAttributesSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["loginId"])
+		require.Equal(t, map[string]any{"department": "R&D"}, req["customAttributes"])
+	}, map[string]any{"user": map[string]any{"email": "a@b.c"}}))
+	res, err := m.User().UpdateWithCustomAttributes("abc", "foo@bar.com", "", "", nil, nil, map[string]any{"department": "R&D"})
+	require.NoError(t, err)
+	require.Equal(t, "a@b.c", res.Email)
+}
+
 func TestUserUpdateError(t *testing.T) {
 	m := newTestMgmt(nil, helpers.DoOk(nil))
 	_, err := m.User().Update("", "foo@bar.com", "", "", nil, nil)
 	require.Error(t, err)
+	_, err = m.User().UpdateWithCustomAttributes("", "foo@bar.com", "", "", nil, nil, nil)
+	require.Error(t, err)
+}
+
+func TestUserUpdateCustomAttributeSuccess(t *testing.T) {
+	response := map[string]any{
+		"user": map[string]any{
+			"customAttributes": map[string]any{"department": "R&D"},
+		}}
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/user/update/customAttribute"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["loginId"])
+		require.Equal(t, "department", req["attributeKey"])
+		require.Equal(t, "R&D", req["attributeValue"])
+	}, response))
+	res, err := m.User().UpdateCustomAttribute("abc", "department", "R&D")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	department, ok := res.CustomAttributes.String("department")
+	require.True(t, ok)
+	require.Equal(t, "R&D", department)
+}
+
+func TestUserUpdateCustomAttributeBadInput(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().UpdateCustomAttribute("", "department", "R&D")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = m.User().UpdateCustomAttribute("abc", "", "R&D")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestUserUpdateCustomAttributeError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := m.User().UpdateCustomAttribute("abc", "department", "R&D")
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestUserCreateWithOptionsSuccess(t *testing.T) {
+	response := map[string]any{
+		"user": map[string]any{
+			"email": "a@b.c",
+		}}
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/user/create"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, map[string]any{
+			"loginId":            "abc",
+			"email":              "a@b.c",
+			"displayName":        "",
+			"picture":            "https://pic",
+			"verifiedEmail":      true,
+			"verifiedPhone":      false,
+			"status":             "invited",
+			"roleNames":          []any{},
+			"userTenants":        []any{map[string]any{"tenantId": "t1", "roleNames": nil}},
+			"customAttributes":   map[string]any{"department": "R&D"},
+			"additionalLoginIds": []any{"abc2"},
+		}, req)
+	}, response))
+	res, err := m.User().CreateWithOptions("abc", &descope.UserRequest{
+		Email:              descope.Ptr("a@b.c"),
+		DisplayName:        descope.Ptr(""),
+		Picture:            descope.Ptr("https://pic"),
+		VerifiedEmail:      descope.Ptr(true),
+		VerifiedPhone:      descope.Ptr(false),
+		Status:             descope.Ptr(descope.UserStatusInvited),
+		Roles:              []string{},
+		Tenants:            []*descope.AssociatedTenant{{TenantID: "t1"}},
+		CustomAttributes:   map[string]any{"department": "R&D"},
+		AdditionalLoginIDs: []string{"abc2"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, "a@b.c", res.Email)
+}
+
+func TestUserCreateWithOptionsNoFields(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, map[string]any{"loginId": "abc"}, req)
+	}, map[string]any{"user": map[string]any{}}))
+	res, err := m.User().CreateWithOptions("abc", nil)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+}
+
+func TestUserCreateWithOptionsError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().CreateWithOptions("", &descope.UserRequest{})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := m.User().CreateWithOptions("abc", &descope.UserRequest{})
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestUserPatchSuccess(t *testing.T) {
+	response := map[string]any{
+		"user": map[string]any{
+			"phone": "+1555",
+		}}
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/user/patch"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, map[string]any{"loginId": "abc", "phone": "+1555", "verifiedPhone": true}, req)
+	}, response))
+	res, err := m.User().Patch("abc", &descope.UserRequest{Phone: descope.Ptr("+1555"), VerifiedPhone: descope.Ptr(true)})
+	require.NoError(t, err)
+	require.Equal(t, "+1555", res.Phone)
+}
+
+func TestUserPatchError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().Patch("", &descope.UserRequest{})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = m.User().Patch("abc", nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := m.User().Patch("abc", &descope.UserRequest{})
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestCreateTestUserSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/user/create/test"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, map[string]any{"loginId": "abc", "email": "a@b.c"}, req)
+	}, map[string]any{"user": map[string]any{"email": "a@b.c"}}))
+	res, err := m.User().CreateTestUser("abc", &descope.UserRequest{Email: descope.Ptr("a@b.c")})
+	require.NoError(t, err)
+	require.Equal(t, "a@b.c", res.Email)
+}
+
+func TestCreateTestUserError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().CreateTestUser("", nil)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	res, err := m.User().CreateTestUser("abc", nil)
+	require.Error(t, err)
+	require.Nil(t, res)
+}
+
+func TestGenerateOTPForTestUserSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/tests/generate/otp"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["loginId"])
+		require.Equal(t, "sms", req["deliveryMethod"])
+	}, map[string]any{"code": "123456", "loginId": "abc"}))
+	code, err := m.User().GenerateOTPForTestUser(descope.MethodSMS, "abc")
+	require.NoError(t, err)
+	require.Equal(t, "123456", code)
+}
+
+func TestGenerateOTPForTestUserError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().GenerateOTPForTestUser(descope.MethodSMS, "")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	code, err := m.User().GenerateOTPForTestUser(descope.MethodSMS, "abc")
+	require.Error(t, err)
+	require.Empty(t, code)
+}
+
+func TestGenerateMagicLinkForTestUserSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/tests/generate/magiclink"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["loginId"])
+		require.Equal(t, "email", req["deliveryMethod"])
+		require.Equal(t, "https://redirect", req["URI"])
+	}, map[string]any{"link": "https://link?t=1"}))
+	link, err := m.User().GenerateMagicLinkForTestUser(descope.MethodEmail, "abc", "https://redirect")
+	require.NoError(t, err)
+	require.Equal(t, "https://link?t=1", link)
+}
+
+func TestGenerateMagicLinkForTestUserError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().GenerateMagicLinkForTestUser(descope.MethodEmail, "", "")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	link, err := m.User().GenerateMagicLinkForTestUser(descope.MethodEmail, "abc", "")
+	require.Error(t, err)
+	require.Empty(t, link)
+}
+
+func TestGenerateEnchantedLinkForTestUserSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/tests/generate/enchantedlink"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["loginId"])
+		require.Equal(t, "https://redirect", req["URI"])
+	}, map[string]any{"link": "https://link?t=1", "pendingRef": "ref"}))
+	link, pendingRef, err := m.User().GenerateEnchantedLinkForTestUser("abc", "https://redirect")
+	require.NoError(t, err)
+	require.Equal(t, "https://link?t=1", link)
+	require.Equal(t, "ref", pendingRef)
+}
+
+func TestGenerateEnchantedLinkForTestUserError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, _, err := m.User().GenerateEnchantedLinkForTestUser("", "")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	link, pendingRef, err := m.User().GenerateEnchantedLinkForTestUser("abc", "")
+	require.Error(t, err)
+	require.Empty(t, link)
+	require.Empty(t, pendingRef)
+}
+
+func TestDeleteAllTestUsersSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.Equal(t, http.MethodDelete, r.Method)
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/user/test/delete/all"))
+	}))
+	err := m.User().DeleteAllTestUsers()
+	require.NoError(t, err)
+}
+
+func TestDeleteAllTestUsersError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err := m.User().DeleteAllTestUsers()
+	require.Error(t, err)
+}
+
+func TestUserImpersonateSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/impersonate"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "support", req["impersonatorId"])
+		require.Equal(t, "abc", req["loginId"])
+		require.Equal(t, true, req["validateConsent"])
+	}, map[string]any{"jwt": "token"}))
+	jwt, err := m.User().Impersonate("support", "abc", true)
+	require.NoError(t, err)
+	require.Equal(t, "token", jwt)
+}
+
+func TestUserImpersonateError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().Impersonate("", "abc", false)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = m.User().Impersonate("support", "", false)
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	_, err = m.User().Impersonate("support", "abc", false)
+	require.Error(t, err)
 }
 
 func TestUserDeleteSuccess(t *testing.T) {
@@ -181,6 +473,134 @@ func TestSearchAllUsersError(t *testing.T) {
 	require.Nil(t, res)
 }
 
+func TestSearchAllUsersWithOptionsSuccess(t *testing.T) {
+	response := map[string]any{
+		"users": []map[string]any{{
+			"email": "a@b.c",
+		}},
+	}
+	options := &descope.UserSearchOptions{
+		Page:             2,
+		Limit:            10,
+		TenantIDs:        []string{"t1"},
+		Emails:           []string{"a@b.c"},
+		Phones:           []string{"+1555"},
+		LoginIDs:         []string{"abc"},
+		Text:             "foo",
+		Statuses:         []descope.UserStatus{descope.UserStatusDisabled},
+		CustomAttributes: map[string]any{"plan": "pro"},
+		Sort:             []descope.UserSearchSort{{Field: "email", Desc: true}},
+	}
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.EqualValues(t, "t1", req["tenantIds"].([]any)[0])
+		require.Nil(t, req["roleNames"])
+		require.EqualValues(t, 10, req["limit"])
+		require.EqualValues(t, 2, req["page"])
+		require.EqualValues(t, "a@b.c", req["emails"].([]any)[0])
+		require.EqualValues(t, "+1555", req["phones"].([]any)[0])
+		require.EqualValues(t, "abc", req["loginIds"].([]any)[0])
+		require.EqualValues(t, "foo", req["text"])
+		require.EqualValues(t, "disabled", req["statuses"].([]any)[0])
+		require.EqualValues(t, "pro", req["customAttributes"].(map[string]any)["plan"])
+		require.EqualValues(t, map[string]any{"field": "email", "desc": true}, req["sort"].([]any)[0])
+	}, response))
+	res, err := m.User().SearchAllWithOptions(options)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "a@b.c", res[0].Email)
+}
+
+func TestSearchAllUsersWithOptionsDefaults(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOkWithBody(func(r *http.Request) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.EqualValues(t, 0, req["limit"])
+		require.EqualValues(t, 0, req["page"])
+		require.NotContains(t, req, "text")
+		require.NotContains(t, req, "sort")
+	}, map[string]any{"users": []any{}}))
+	res, err := m.User().SearchAllWithOptions(nil)
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
+func TestSearchAllUsersWithOptionsBadInput(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	_, err := m.User().SearchAllWithOptions(&descope.UserSearchOptions{Page: -1})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = m.User().SearchAllWithOptions(&descope.UserSearchOptions{Limit: -1})
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestSearchIteratorSuccess(t *testing.T) {
+	pages := [][]map[string]any{
+		{{"email": "3@b.c"}, {"email": "4@b.c"}},
+		{{"email": "5@b.c"}},
+	}
+	calls := 0
+	m := newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.EqualValues(t, 2, req["limit"])
+		require.EqualValues(t, calls+1, req["page"])
+		require.EqualValues(t, "t1", req["tenantIds"].([]any)[0])
+		response := map[string]any{"users": pages[calls]}
+		calls++
+		return helpers.DoOkWithBody(nil, response)(r)
+	})
+	options := &descope.UserSearchOptions{Page: 1, Limit: 2, TenantIDs: []string{"t1"}}
+	it := m.User().SearchIterator(options)
+	emails := []string{}
+	for it.Next() {
+		emails = append(emails, it.User().Email)
+	}
+	require.NoError(t, it.Err())
+	require.EqualValues(t, []string{"3@b.c", "4@b.c", "5@b.c"}, emails)
+	// the short last page ends the walk without loading another page
+	require.Equal(t, 2, calls)
+	require.Nil(t, it.User())
+	require.False(t, it.Next())
+	// the options passed by the caller are not modified
+	require.EqualValues(t, 1, options.Page)
+}
+
+func TestSearchIteratorFullLastPage(t *testing.T) {
+	calls := 0
+	m := newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.EqualValues(t, 100, req["limit"])
+		users := []map[string]any{}
+		if calls == 0 {
+			for i := 0; i < 100; i++ {
+				users = append(users, map[string]any{"email": "a@b.c"})
+			}
+		}
+		calls++
+		return helpers.DoOkWithBody(nil, map[string]any{"users": users})(r)
+	})
+	it := m.User().SearchIterator(nil)
+	count := 0
+	for it.Next() {
+		count++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, 100, count)
+	require.Equal(t, 2, calls)
+}
+
+func TestSearchIteratorError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoBadRequest(nil))
+	it := m.User().SearchIterator(nil)
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+	require.Nil(t, it.User())
+	require.False(t, it.Next())
+}
+
 func TestUserActivateSuccess(t *testing.T) {
 	response := map[string]any{
 		"user": map[string]any{
@@ -562,3 +982,50 @@ func TestUserRemoveTenantRoleError(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, res)
 }
+
+func TestUserSetPasswordSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/user/password/set"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["loginId"])
+		require.Equal(t, "secret", req["password"])
+	}))
+	err := m.User().SetPassword("abc", "secret")
+	require.NoError(t, err)
+}
+
+func TestUserSetPasswordError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	err := m.User().SetPassword("", "secret")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+	err = m.User().SetPassword("abc", "")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = m.User().SetPassword("abc", "secret")
+	require.Error(t, err)
+}
+
+func TestUserExpirePasswordSuccess(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(func(r *http.Request) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		require.True(t, strings.HasSuffix(r.URL.Path, "mgmt/user/password/expire"))
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		require.Equal(t, "abc", req["loginId"])
+	}))
+	err := m.User().ExpirePassword("abc")
+	require.NoError(t, err)
+}
+
+func TestUserExpirePasswordError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	err := m.User().ExpirePassword("")
+	require.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	m = newTestMgmt(nil, helpers.DoBadRequest(nil))
+	err = m.User().ExpirePassword("abc")
+	require.Error(t, err)
+}
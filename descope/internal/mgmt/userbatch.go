@@ -0,0 +1,211 @@
+package mgmt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/logger"
+)
+
+const (
+	defaultBatchWorkers    = 4
+	defaultBatchMaxRetries = 3
+)
+
+// How long to wait before retrying a rate limited operation when the server
+// doesn't say, multiplied by the number of attempts so far
+var batchRetryDelay = time.Second
+
+// A single line in a batch checkpoint file
+type batchCheckpointEntry struct {
+	Index     int                        `json:"index"`
+	Operation descope.UserBatchOperation `json:"operation"`
+	LoginID   string                     `json:"loginId"`
+}
+
+func (e *batchCheckpointEntry) key() string {
+	return fmt.Sprintf("%d:%s:%s", e.Index, e.Operation, e.LoginID)
+}
+
+type userBatch struct {
+	user       *user
+	maxRetries int
+
+	mutex    sync.Mutex
+	resumeAt time.Time
+}
+
+func (u *user) Batch(items []*descope.UserBatchItem, options *descope.UserBatchOptions) ([]*descope.UserBatchResult, error) {
+	if options == nil {
+		options = &descope.UserBatchOptions{}
+	}
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = defaultBatchWorkers
+	}
+	batch := &userBatch{user: u, maxRetries: options.MaxRetries}
+	if batch.maxRetries == 0 {
+		batch.maxRetries = defaultBatchMaxRetries
+	} else if batch.maxRetries < 0 {
+		batch.maxRetries = 0
+	}
+
+	completed := map[string]bool{}
+	var checkpoint *os.File
+	if options.CheckpointFile != "" {
+		var err error
+		if completed, err = readBatchCheckpoint(options.CheckpointFile); err != nil {
+			return nil, err
+		}
+		if checkpoint, err = os.OpenFile(options.CheckpointFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600); err != nil {
+			return nil, err
+		}
+		defer checkpoint.Close()
+	}
+
+	results := make([]*descope.UserBatchResult, len(items))
+	var checkpointErr error
+	var resultsMutex sync.Mutex
+	report := func(result *descope.UserBatchResult) {
+		resultsMutex.Lock()
+		defer resultsMutex.Unlock()
+		results[result.Index] = result
+		if checkpoint != nil && checkpointErr == nil && result.Err == nil && !result.Skipped {
+			checkpointErr = writeBatchCheckpoint(checkpoint, result)
+		}
+		if options.OnResult != nil {
+			options.OnResult(result)
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				res, err := batch.perform(items[i])
+				report(&descope.UserBatchResult{Index: i, Item: items[i], User: res, Err: err})
+			}
+		}()
+	}
+
+	for i, item := range items {
+		if item == nil {
+			report(&descope.UserBatchResult{Index: i, Err: utils.NewInvalidArgumentError("item")})
+			continue
+		}
+		entry := &batchCheckpointEntry{Index: i, Operation: item.Operation, LoginID: item.LoginID}
+		if completed[entry.key()] {
+			report(&descope.UserBatchResult{Index: i, Item: item, Skipped: true})
+			continue
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, checkpointErr
+}
+
+// Performs a single operation, retrying it if it fails due to rate limits
+func (b *userBatch) perform(item *descope.UserBatchItem) (*descope.UserResponse, error) {
+	for attempt := 0; ; attempt++ {
+		b.waitForResume()
+		res, err := b.user.performBatchItem(item)
+		if err == nil || !descope.IsRateLimited(err) || attempt >= b.maxRetries {
+			return res, err
+		}
+		delay := descope.RetryAfter(err)
+		if delay == 0 {
+			delay = batchRetryDelay * time.Duration(attempt+1)
+		}
+		logger.LogDebug("Batch operation for user %s was rate limited, retrying in %s", item.LoginID, delay)
+		b.pauseFor(delay)
+	}
+}
+
+// Pauses all workers until the given duration passes
+func (b *userBatch) pauseFor(delay time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if resumeAt := time.Now().Add(delay); resumeAt.After(b.resumeAt) {
+		b.resumeAt = resumeAt
+	}
+}
+
+func (b *userBatch) waitForResume() {
+	b.mutex.Lock()
+	delay := time.Until(b.resumeAt)
+	b.mutex.Unlock()
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+func (u *user) performBatchItem(item *descope.UserBatchItem) (*descope.UserResponse, error) {
+	switch item.Operation {
+	case descope.UserBatchCreate:
+		return u.CreateWithCustomAttributes(item.LoginID, item.Email, item.Phone, item.DisplayName, item.Roles, item.Tenants, item.CustomAttributes)
+	case descope.UserBatchUpdate:
+		return u.UpdateWithCustomAttributes(item.LoginID, item.Email, item.Phone, item.DisplayName, item.Roles, item.Tenants, item.CustomAttributes)
+	case descope.UserBatchDelete:
+		return nil, u.Delete(item.LoginID)
+	case descope.UserBatchAddRoles:
+		return u.AddRoles(item.LoginID, item.Roles)
+	case descope.UserBatchRemoveRoles:
+		return u.RemoveRoles(item.LoginID, item.Roles)
+	case descope.UserBatchAddTenant:
+		return u.AddTenant(item.LoginID, item.TenantID)
+	case descope.UserBatchRemoveTenant:
+		return u.RemoveTenant(item.LoginID, item.TenantID)
+	case descope.UserBatchAddTenantRoles:
+		return u.AddTenantRoles(item.LoginID, item.TenantID, item.Roles)
+	case descope.UserBatchRemoveTenantRoles:
+		return u.RemoveTenantRoles(item.LoginID, item.TenantID, item.Roles)
+	}
+	return nil, utils.NewInvalidArgumentError("operation")
+}
+
+func readBatchCheckpoint(path string) (map[string]bool, error) {
+	completed := map[string]bool{}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return completed, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &batchCheckpointEntry{}
+		if err := utils.Unmarshal(scanner.Bytes(), entry); err != nil {
+			// the last line might be partial if the previous run was interrupted while writing it
+			logger.LogDebug("Ignoring invalid line in batch checkpoint file: %s", scanner.Text())
+			continue
+		}
+		completed[entry.key()] = true
+	}
+	return completed, scanner.Err()
+}
+
+func writeBatchCheckpoint(file *os.File, result *descope.UserBatchResult) error {
+	b, err := utils.Marshal(&batchCheckpointEntry{Index: result.Index, Operation: result.Item.Operation, LoginID: result.Item.LoginID})
+	if err != nil { // notest
+		return err
+	}
+	_, err = file.Write(append(b, '\n'))
+	return err
+}
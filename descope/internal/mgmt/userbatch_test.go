@@ -0,0 +1,178 @@
+package mgmt
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/tests/helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func doRateLimited(retryAfter string) (*http.Response, error) {
+	res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}, Body: io.NopCloser(bytes.NewBufferString(`{"errorCode":"E130429"}`))}
+	if retryAfter != "" {
+		res.Header.Set("Retry-After", retryAfter)
+	}
+	return res, nil
+}
+
+func TestUserBatchSuccess(t *testing.T) {
+	var mutex sync.Mutex
+	paths := map[string]string{}
+	m := newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, r.Header.Get("Authorization"), "Bearer a:key")
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		mutex.Lock()
+		paths[req["loginId"].(string)] = r.URL.Path
+		mutex.Unlock()
+		if req["loginId"] == "bad" {
+			return helpers.DoBadRequest(nil)(r)
+		}
+		return helpers.DoOkWithBody(nil, map[string]any{"user": map[string]any{"loginIds": []string{req["loginId"].(string)}}})(r)
+	})
+	items := []*descope.UserBatchItem{
+		{Operation: descope.UserBatchCreate, LoginID: "a", Email: "a@b.c"},
+		{Operation: descope.UserBatchUpdate, LoginID: "b"},
+		{Operation: descope.UserBatchDelete, LoginID: "c"},
+		{Operation: descope.UserBatchAddRoles, LoginID: "d", Roles: []string{"r"}},
+		{Operation: descope.UserBatchRemoveRoles, LoginID: "e", Roles: []string{"r"}},
+		{Operation: descope.UserBatchAddTenant, LoginID: "f", TenantID: "t"},
+		{Operation: descope.UserBatchRemoveTenant, LoginID: "g", TenantID: "t"},
+		{Operation: descope.UserBatchAddTenantRoles, LoginID: "h", TenantID: "t", Roles: []string{"r"}},
+		{Operation: descope.UserBatchRemoveTenantRoles, LoginID: "i", TenantID: "t", Roles: []string{"r"}},
+		{Operation: descope.UserBatchCreate, LoginID: "bad"},
+		{Operation: "foo", LoginID: "j"},
+		nil,
+	}
+	reported := 0
+	results, err := m.User().Batch(items, &descope.UserBatchOptions{Workers: 3, OnResult: func(result *descope.UserBatchResult) { reported++ }})
+	require.NoError(t, err)
+	require.Len(t, results, len(items))
+	require.Equal(t, len(items), reported)
+	for i, result := range results {
+		require.Equal(t, i, result.Index)
+		require.Equal(t, items[i], result.Item)
+		require.False(t, result.Skipped)
+	}
+	for i := 0; i < 9; i++ {
+		require.NoError(t, results[i].Err)
+	}
+	require.Equal(t, "a", results[0].User.LoginIDs[0])
+	require.Nil(t, results[2].User)
+	require.Error(t, results[9].Err)
+	require.ErrorIs(t, results[10].Err, descope.ErrInvalidArguments)
+	require.ErrorIs(t, results[11].Err, descope.ErrInvalidArguments)
+
+	require.True(t, strings.HasSuffix(paths["a"], "user/create"))
+	require.True(t, strings.HasSuffix(paths["b"], "user/update"))
+	require.True(t, strings.HasSuffix(paths["c"], "user/delete"))
+	require.True(t, strings.HasSuffix(paths["f"], "user/update/tenant/add"))
+	require.NotContains(t, paths, "j")
+}
+
+func TestUserBatchRateLimitRetry(t *testing.T) {
+	defer func(delay time.Duration) { batchRetryDelay = delay }(batchRetryDelay)
+	batchRetryDelay = time.Millisecond
+
+	var calls int32
+	m := newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			return doRateLimited("")
+		}
+		return helpers.DoOkWithBody(nil, map[string]any{"user": map[string]any{}})(r)
+	})
+	items := []*descope.UserBatchItem{{Operation: descope.UserBatchCreate, LoginID: "a"}}
+	results, err := m.User().Batch(items, nil)
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	require.EqualValues(t, 3, calls)
+}
+
+func TestUserBatchRateLimitExhausted(t *testing.T) {
+	defer func(delay time.Duration) { batchRetryDelay = delay }(batchRetryDelay)
+	batchRetryDelay = time.Millisecond
+
+	var calls int32
+	m := newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return doRateLimited("")
+	})
+	items := []*descope.UserBatchItem{{Operation: descope.UserBatchDelete, LoginID: "a"}}
+	results, err := m.User().Batch(items, &descope.UserBatchOptions{MaxRetries: 1})
+	require.NoError(t, err)
+	require.True(t, descope.IsRateLimited(results[0].Err))
+	require.EqualValues(t, 2, calls)
+
+	calls = 0
+	results, err = m.User().Batch(items, &descope.UserBatchOptions{MaxRetries: -1})
+	require.NoError(t, err)
+	require.True(t, descope.IsRateLimited(results[0].Err))
+	require.EqualValues(t, 1, calls)
+}
+
+func TestUserBatchCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	var mutex sync.Mutex
+	calls := map[string]int{}
+	failing := "b"
+	m := newTestMgmt(nil, func(r *http.Request) (*http.Response, error) {
+		req := map[string]any{}
+		require.NoError(t, helpers.ReadBody(r, &req))
+		loginID := req["loginId"].(string)
+		mutex.Lock()
+		calls[loginID]++
+		fail := loginID == failing
+		mutex.Unlock()
+		if fail {
+			return helpers.DoBadRequest(nil)(r)
+		}
+		return helpers.DoOkWithBody(nil, map[string]any{"user": map[string]any{}})(r)
+	})
+	items := []*descope.UserBatchItem{
+		{Operation: descope.UserBatchCreate, LoginID: "a"},
+		{Operation: descope.UserBatchCreate, LoginID: "b"},
+		{Operation: descope.UserBatchCreate, LoginID: "c"},
+	}
+	options := &descope.UserBatchOptions{CheckpointFile: path}
+
+	results, err := m.User().Batch(items, options)
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	require.Error(t, results[1].Err)
+	require.NoError(t, results[2].Err)
+
+	// simulate a partial line written by an interrupted run
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"index":1,"opera`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	failing = ""
+	results, err = m.User().Batch(items, options)
+	require.NoError(t, err)
+	require.True(t, results[0].Skipped)
+	require.False(t, results[1].Skipped)
+	require.NoError(t, results[1].Err)
+	require.True(t, results[2].Skipped)
+	require.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1}, calls)
+}
+
+func TestUserBatchCheckpointError(t *testing.T) {
+	m := newTestMgmt(nil, helpers.DoOk(nil))
+	dir := t.TempDir()
+	_, err := m.User().Batch(nil, &descope.UserBatchOptions{CheckpointFile: dir})
+	require.Error(t, err)
+	_, err = m.User().Batch(nil, &descope.UserBatchOptions{CheckpointFile: filepath.Join(dir, "missing", "checkpoint.jsonl")})
+	require.Error(t, err)
+}
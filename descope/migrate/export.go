@@ -0,0 +1,140 @@
+package migrate
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+	"github.com/descope/go-sdk/descope/sdk"
+)
+
+// Writer writes records in either CSV or JSONL format, using the same field
+// mapping and separators as when reading them.
+type Writer struct {
+	options *Options
+	csv     *csv.Writer
+	w       io.Writer
+}
+
+// NewWriter returns a writer for the given options. When writing CSV the header
+// row is written along with the first record.
+func NewWriter(w io.Writer, options *Options) (*Writer, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	writer := &Writer{options: options, w: w}
+	switch options.Format {
+	case FormatCSV, "":
+		writer.csv = csv.NewWriter(w)
+		header := []string{}
+		for _, field := range fields {
+			header = append(header, options.Mapping.column(field))
+		}
+		if err := writer.csv.Write(header); err != nil {
+			return nil, err
+		}
+	case FormatJSONL:
+	default:
+		return nil, utils.NewInvalidArgumentError("format")
+	}
+	return writer, nil
+}
+
+func (w *Writer) Write(record *Record) error {
+	if w.csv != nil {
+		return w.writeCSV(record)
+	}
+	return w.writeJSONL(record)
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+func (w *Writer) writeCSV(record *Record) error {
+	tenants := []string{}
+	for _, tenant := range record.Tenants {
+		entry := tenant.TenantID
+		if len(tenant.Roles) > 0 {
+			entry += ":" + strings.Join(tenant.Roles, "|")
+		}
+		tenants = append(tenants, entry)
+	}
+	separator := w.options.listSeparator()
+	return w.csv.Write([]string{
+		record.LoginID,
+		record.Email,
+		record.Phone,
+		record.Name,
+		strings.Join(record.Roles, separator),
+		strings.Join(tenants, separator),
+	})
+}
+
+func (w *Writer) writeJSONL(record *Record) error {
+	values := map[string]any{w.options.Mapping.column(FieldLoginID): record.LoginID}
+	optional := map[Field]string{FieldEmail: record.Email, FieldPhone: record.Phone, FieldName: record.Name}
+	for field, value := range optional {
+		if value != "" {
+			values[w.options.Mapping.column(field)] = value
+		}
+	}
+	if len(record.Roles) > 0 {
+		values[w.options.Mapping.column(FieldRoles)] = record.Roles
+	}
+	if len(record.Tenants) > 0 {
+		values[w.options.Mapping.column(FieldTenants)] = record.Tenants
+	}
+	b, err := utils.Marshal(values)
+	if err != nil { // notest
+		return err
+	}
+	_, err = w.w.Write(append(b, '\n'))
+	return err
+}
+
+// RecordFromUser converts a user returned by the management APIs into a record.
+// Users with multiple login IDs are exported with the first one.
+func RecordFromUser(user *descope.UserResponse) *Record {
+	record := &Record{
+		Email:   user.Email,
+		Phone:   user.Phone,
+		Name:    user.Name,
+		Roles:   user.RoleNames,
+		Tenants: user.UserTenants,
+	}
+	if len(user.LoginIDs) > 0 {
+		record.LoginID = user.LoginIDs[0]
+	}
+	return record
+}
+
+// Export writes all the users matching the search options, loading them one page
+// at a time. The search parameter is optional, and when nil all users are exported.
+// Returns the number of users written, which is also set when an error occurs.
+func Export(mgmt sdk.Management, w io.Writer, search *descope.UserSearchOptions, options *Options) (int, error) {
+	writer, err := NewWriter(w, options)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	it := mgmt.User().SearchIterator(search)
+	for it.Next() {
+		if err := writer.Write(RecordFromUser(it.User())); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		writer.Flush()
+		return count, err
+	}
+	return count, writer.Flush()
+}
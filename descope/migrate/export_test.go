@@ -0,0 +1,88 @@
+package migrate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	mocksmgmt "github.com/descope/go-sdk/descope/tests/mocks/mgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exportUsers = []*descope.UserResponse{
+	{
+		User:        descope.User{Name: "John", Email: "john@acme.com"},
+		LoginIDs:    []string{"john@acme.com", "john"},
+		RoleNames:   []string{"dev", "tester"},
+		UserTenants: []*descope.AssociatedTenant{{TenantID: "t1", Roles: []string{"admin", "viewer"}}, {TenantID: "t2"}},
+	},
+	{
+		User:     descope.User{Phone: "5551234"},
+		LoginIDs: []string{"stan"},
+	},
+}
+
+func TestExportCSV(t *testing.T) {
+	var search *descope.UserSearchOptions
+	mgmt := &mocksmgmt.MockManagement{MockUser: &mocksmgmt.MockUser{
+		SearchIteratorAssert:   func(options *descope.UserSearchOptions) { search = options },
+		SearchIteratorResponse: exportUsers,
+	}}
+	buf := &bytes.Buffer{}
+	options := &descope.UserSearchOptions{TenantIDs: []string{"t1"}}
+	count, err := Export(mgmt, buf, options, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, options, search)
+	assert.Equal(t, `loginId,email,phone,name,roles,tenants
+john@acme.com,john@acme.com,,John,dev;tester,t1:admin|viewer;t2
+stan,,5551234,,,
+`, buf.String())
+
+	// exported data can be read back
+	res, err := Read(buf, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Records, 2)
+	assert.EqualValues(t, exportUsers[0].UserTenants, res.Records[0].Tenants)
+}
+
+func TestExportJSONL(t *testing.T) {
+	mgmt := &mocksmgmt.MockManagement{MockUser: &mocksmgmt.MockUser{SearchIteratorResponse: exportUsers}}
+	buf := &bytes.Buffer{}
+	options := &Options{Format: FormatJSONL, Mapping: Mapping{FieldLoginID: "id"}}
+	count, err := Export(mgmt, buf, nil, options)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"id":"john@acme.com","email":"john@acme.com","name":"John","roles":["dev","tester"],"tenants":[{"tenantId":"t1","roleNames":["admin","viewer"]},{"tenantId":"t2"}]}`, lines[0])
+	assert.JSONEq(t, `{"id":"stan","phone":"5551234"}`, lines[1])
+
+	res, err := Read(buf, options)
+	require.NoError(t, err)
+	require.Len(t, res.Records, 2)
+	assert.Equal(t, "stan", res.Records[1].LoginID)
+}
+
+func TestExportError(t *testing.T) {
+	mgmt := &mocksmgmt.MockManagement{MockUser: &mocksmgmt.MockUser{
+		SearchIteratorResponse: exportUsers[:1],
+		SearchIteratorError:    descope.ErrRateLimitExceeded,
+	}}
+	buf := &bytes.Buffer{}
+	count, err := Export(mgmt, buf, nil, nil)
+	assert.ErrorIs(t, err, descope.ErrRateLimitExceeded)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, buf.String(), "john@acme.com")
+
+	_, err = Export(mgmt, buf, nil, &Options{Format: "xml"})
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestRecordFromUserWithoutLoginID(t *testing.T) {
+	record := RecordFromUser(&descope.UserResponse{User: descope.User{Email: "a@b.c"}})
+	assert.Empty(t, record.LoginID)
+	assert.Error(t, record.Validate())
+}
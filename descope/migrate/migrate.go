@@ -0,0 +1,293 @@
+// Package migrate reads and writes users in CSV and JSONL formats, e.g., for
+// importing users from another identity provider or exporting them for backup.
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/internal/utils"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// Field is a user field that can be read from and written to a column in a CSV
+// file or a key in a JSONL object.
+type Field string
+
+const (
+	FieldLoginID Field = "loginId"
+	FieldEmail   Field = "email"
+	FieldPhone   Field = "phone"
+	FieldName    Field = "name"
+	FieldRoles   Field = "roles"
+	FieldTenants Field = "tenants"
+)
+
+// The order of the fields when writing CSV columns
+var fields = []Field{FieldLoginID, FieldEmail, FieldPhone, FieldName, FieldRoles, FieldTenants}
+
+// Mapping sets the CSV column or JSONL key for each field. Fields that aren't in
+// the mapping use the field name itself.
+type Mapping map[Field]string
+
+func (m Mapping) column(field Field) string {
+	if name := m[field]; name != "" {
+		return name
+	}
+	return string(field)
+}
+
+type Options struct {
+	// The format of the data, defaults to CSV.
+	Format Format
+	// An optional mapping of fields to column names or JSON keys.
+	Mapping Mapping
+	// The separator between values in the roles and tenants CSV columns, defaults to ";".
+	//
+	// Each value in the tenants column is a tenant ID, optionally followed by a colon
+	// and the tenant's roles separated by "|", e.g., "tenant1:admin|viewer;tenant2".
+	// In JSONL the roles are an array of strings and the tenants are an array of objects
+	// with the same fields as descope.AssociatedTenant.
+	ListSeparator string
+}
+
+func (o *Options) listSeparator() string {
+	if o.ListSeparator != "" {
+		return o.ListSeparator
+	}
+	return ";"
+}
+
+// Record is a single user read from or written to a file.
+type Record struct {
+	// The line number in the input, starting from 1. Not set when exporting users.
+	Line    int
+	LoginID string
+	Email   string
+	Phone   string
+	Name    string
+	Roles   []string
+	Tenants []*descope.AssociatedTenant
+}
+
+// Validate ensures the record has a login ID, and that the email and phone are
+// valid if they are set.
+func (r *Record) Validate() error {
+	if r.LoginID == "" {
+		return descope.ErrValidationFailure.WithMessage("Missing login ID")
+	}
+	if r.Email != "" && !utils.EmailRegex.MatchString(r.Email) {
+		return descope.ErrValidationFailure.WithMessage("Invalid email %s", r.Email)
+	}
+	if r.Phone != "" && !utils.PhoneRegex.MatchString(r.Phone) {
+		return descope.ErrValidationFailure.WithMessage("Invalid phone %s", r.Phone)
+	}
+	for _, tenant := range r.Tenants {
+		if tenant == nil || tenant.TenantID == "" {
+			return descope.ErrValidationFailure.WithMessage("Missing tenant ID")
+		}
+	}
+	return nil
+}
+
+// BatchItem returns an operation that creates the user, to be passed to User().Batch.
+func (r *Record) BatchItem() *descope.UserBatchItem {
+	return &descope.UserBatchItem{
+		Operation:   descope.UserBatchCreate,
+		LoginID:     r.LoginID,
+		Email:       r.Email,
+		Phone:       r.Phone,
+		DisplayName: r.Name,
+		Roles:       r.Roles,
+		Tenants:     r.Tenants,
+	}
+}
+
+// InvalidRecord is a line in the input that couldn't be parsed or failed validation.
+type InvalidRecord struct {
+	Line int
+	Err  error
+}
+
+func (r *InvalidRecord) String() string {
+	return fmt.Sprintf("line %d: %s", r.Line, r.Err)
+}
+
+// Result holds the valid records and the invalid ones in the order they appear in the input.
+type Result struct {
+	Records []*Record
+	Invalid []*InvalidRecord
+}
+
+// BatchItems returns operations that create all the valid users, to be passed to User().Batch.
+func (r *Result) BatchItems() []*descope.UserBatchItem {
+	items := []*descope.UserBatchItem{}
+	for _, record := range r.Records {
+		items = append(items, record.BatchItem())
+	}
+	return items
+}
+
+func (r *Result) add(record *Record, seen map[string]int) {
+	if err := record.Validate(); err != nil {
+		r.invalid(record.Line, err)
+		return
+	}
+	if line, ok := seen[record.LoginID]; ok {
+		r.invalid(record.Line, descope.ErrValidationFailure.WithMessage("Duplicate login ID %s, first seen on line %d", record.LoginID, line))
+		return
+	}
+	seen[record.LoginID] = record.Line
+	r.Records = append(r.Records, record)
+}
+
+func (r *Result) invalid(line int, err error) {
+	r.Invalid = append(r.Invalid, &InvalidRecord{Line: line, Err: err})
+}
+
+// Read parses and validates users from the reader. Records that fail to parse or
+// validate are reported in the Invalid list of the result, while the returned error
+// is only set when the input can't be read at all.
+func Read(r io.Reader, options *Options) (*Result, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	switch options.Format {
+	case FormatCSV, "":
+		return readCSV(r, options)
+	case FormatJSONL:
+		return readJSONL(r, options)
+	}
+	return nil, utils.NewInvalidArgumentError("format")
+}
+
+func readCSV(r io.Reader, options *Options) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return &Result{}, nil
+	} else if err != nil {
+		return nil, descope.ErrInvalidArguments.WithMessage("Failed to read CSV header").WithCause(err)
+	}
+	columns := map[Field]int{}
+	for _, field := range fields {
+		columns[field] = -1
+		for i, name := range header {
+			if strings.TrimSpace(name) == options.Mapping.column(field) {
+				columns[field] = i
+			}
+		}
+	}
+	if columns[FieldLoginID] < 0 {
+		return nil, descope.ErrInvalidArguments.WithMessage("Missing login ID column %s", options.Mapping.column(FieldLoginID))
+	}
+
+	result := &Result{}
+	seen := map[string]int{}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, descope.ErrInvalidArguments.WithMessage("Failed to read CSV").WithCause(err)
+		}
+		line, _ := reader.FieldPos(0)
+		value := func(field Field) string {
+			if i := columns[field]; i >= 0 && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		record := &Record{
+			Line:    line,
+			LoginID: value(FieldLoginID),
+			Email:   value(FieldEmail),
+			Phone:   value(FieldPhone),
+			Name:    value(FieldName),
+			Roles:   splitList(value(FieldRoles), options.listSeparator()),
+		}
+		for _, entry := range splitList(value(FieldTenants), options.listSeparator()) {
+			tenantID, roles, _ := strings.Cut(entry, ":")
+			record.Tenants = append(record.Tenants, &descope.AssociatedTenant{TenantID: strings.TrimSpace(tenantID), Roles: splitList(roles, "|")})
+		}
+		result.add(record, seen)
+	}
+	return result, nil
+}
+
+func readJSONL(r io.Reader, options *Options) (*Result, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	result := &Result{}
+	seen := map[string]int{}
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		record, err := parseJSONRecord(data, options)
+		if err != nil {
+			result.invalid(line, err)
+			continue
+		}
+		record.Line = line
+		result.add(record, seen)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, descope.ErrInvalidArguments.WithMessage("Failed to read JSONL").WithCause(err)
+	}
+	return result, nil
+}
+
+func parseJSONRecord(data []byte, options *Options) (*Record, error) {
+	values := map[string]json.RawMessage{}
+	if err := utils.Unmarshal(data, &values); err != nil {
+		return nil, descope.ErrValidationFailure.WithMessage("Invalid JSON").WithCause(err)
+	}
+	record := &Record{}
+	targets := map[Field]any{
+		FieldLoginID: &record.LoginID,
+		FieldEmail:   &record.Email,
+		FieldPhone:   &record.Phone,
+		FieldName:    &record.Name,
+		FieldRoles:   &record.Roles,
+		FieldTenants: &record.Tenants,
+	}
+	for _, field := range fields {
+		value, ok := values[options.Mapping.column(field)]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		if err := utils.Unmarshal(value, targets[field]); err != nil {
+			return nil, descope.ErrValidationFailure.WithMessage("Invalid value for %s", options.Mapping.column(field)).WithCause(err)
+		}
+	}
+	return record, nil
+}
+
+func splitList(value, separator string) []string {
+	var list []string
+	for _, item := range strings.Split(value, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,132 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	data := `loginId,email,phone,name,roles,tenants,ignored
+john@acme.com,john@acme.com,,John,dev;tester,t1:admin|viewer;t2,x
+stan,,+1 555 1234,"Stan, Acme",,,
+`
+	res, err := Read(strings.NewReader(data), nil)
+	require.NoError(t, err)
+	require.Empty(t, res.Invalid)
+	require.Len(t, res.Records, 2)
+
+	john := res.Records[0]
+	assert.Equal(t, 2, john.Line)
+	assert.Equal(t, "john@acme.com", john.LoginID)
+	assert.Equal(t, "John", john.Name)
+	assert.EqualValues(t, []string{"dev", "tester"}, john.Roles)
+	require.Len(t, john.Tenants, 2)
+	assert.Equal(t, "t1", john.Tenants[0].TenantID)
+	assert.EqualValues(t, []string{"admin", "viewer"}, john.Tenants[0].Roles)
+	assert.Equal(t, "t2", john.Tenants[1].TenantID)
+	assert.Nil(t, john.Tenants[1].Roles)
+
+	stan := res.Records[1]
+	assert.Equal(t, 3, stan.Line)
+	assert.Equal(t, "+1 555 1234", stan.Phone)
+	assert.Equal(t, "Stan, Acme", stan.Name)
+	assert.Nil(t, stan.Roles)
+	assert.Nil(t, stan.Tenants)
+
+	items := res.BatchItems()
+	require.Len(t, items, 2)
+	assert.Equal(t, descope.UserBatchCreate, items[0].Operation)
+	assert.Equal(t, "John", items[0].DisplayName)
+	assert.Equal(t, john.Tenants, items[0].Tenants)
+}
+
+func TestReadCSVMapping(t *testing.T) {
+	data := "Username,E-Mail,Groups\nabc,abc@acme.com,a,b\n"
+	options := &Options{Mapping: Mapping{FieldLoginID: "Username", FieldEmail: "E-Mail", FieldRoles: "Groups"}, ListSeparator: ","}
+	res, err := Read(strings.NewReader(data), options)
+	require.NoError(t, err)
+	require.Len(t, res.Records, 1)
+	assert.Equal(t, "abc", res.Records[0].LoginID)
+	assert.Equal(t, "abc@acme.com", res.Records[0].Email)
+	// extra values beyond the header are ignored, separators must be quoted
+	assert.EqualValues(t, []string{"a"}, res.Records[0].Roles)
+}
+
+func TestReadCSVInvalidRows(t *testing.T) {
+	data := `loginId,email,phone,tenants
+,a@acme.com,,
+b,not-an-email,,
+c,,abc,
+d,,,:admin
+e,,,
+e,,,
+`
+	res, err := Read(strings.NewReader(data), &Options{Format: FormatCSV})
+	require.NoError(t, err)
+	require.Len(t, res.Records, 1)
+	assert.Equal(t, "e", res.Records[0].LoginID)
+	require.Len(t, res.Invalid, 5)
+	for i, line := range []int{2, 3, 4, 5, 7} {
+		assert.Equal(t, line, res.Invalid[i].Line)
+		assert.ErrorIs(t, res.Invalid[i].Err, descope.ErrValidationFailure)
+	}
+	assert.Contains(t, res.Invalid[4].String(), "line 7")
+	assert.Contains(t, res.Invalid[4].Err.Error(), "first seen on line 6")
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	res, err := Read(strings.NewReader(""), nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Records)
+
+	_, err = Read(strings.NewReader("email\na@b.c\n"), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	_, err = Read(strings.NewReader("loginId\n\"abc\n"), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	_, err = Read(strings.NewReader("loginId\"\n"), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	_, err = Read(strings.NewReader(""), &Options{Format: "xml"})
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestReadJSONL(t *testing.T) {
+	data := `{"loginId":"john","email":"john@acme.com","roles":["dev"],"tenants":[{"tenantId":"t1","roleNames":["admin"]}]}
+
+{"loginId":"stan","phone":"5551234","name":null}
+{"loginId":"bad","email":"foo"}
+{"loginId":"bad2","roles":"dev"}
+not json
+`
+	res, err := Read(strings.NewReader(data), &Options{Format: FormatJSONL})
+	require.NoError(t, err)
+	require.Len(t, res.Records, 2)
+	assert.Equal(t, 1, res.Records[0].Line)
+	assert.EqualValues(t, []string{"dev"}, res.Records[0].Roles)
+	assert.EqualValues(t, []string{"admin"}, res.Records[0].Tenants[0].Roles)
+	assert.Equal(t, 3, res.Records[1].Line)
+	assert.Equal(t, "5551234", res.Records[1].Phone)
+
+	require.Len(t, res.Invalid, 3)
+	assert.Equal(t, 4, res.Invalid[0].Line)
+	assert.Equal(t, 5, res.Invalid[1].Line)
+	assert.Equal(t, 6, res.Invalid[2].Line)
+	for _, invalid := range res.Invalid {
+		assert.ErrorIs(t, invalid.Err, descope.ErrValidationFailure)
+	}
+}
+
+func TestReadJSONLMapping(t *testing.T) {
+	data := `{"user":"abc","mail":"abc@acme.com","loginId":"ignored"}`
+	res, err := Read(strings.NewReader(data), &Options{Format: FormatJSONL, Mapping: Mapping{FieldLoginID: "user", FieldEmail: "mail"}})
+	require.NoError(t, err)
+	require.Len(t, res.Records, 1)
+	assert.Equal(t, "abc", res.Records[0].LoginID)
+	assert.Equal(t, "abc@acme.com", res.Records[0].Email)
+}
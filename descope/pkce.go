@@ -0,0 +1,35 @@
+package descope
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// The code challenge method used for PKCE, the only one supported by the SDK
+const PKCEMethodS256 = "S256"
+
+// A PKCE (Proof Key for Code Exchange) pair, as described in RFC 7636. Clients that can't
+// keep a secret or a cookie, such as native apps and command line tools, send the challenge
+// when starting an OAuth authentication and then prove they started it with the verifier
+// when exchanging the code.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE generates a random code verifier and its S256 code challenge.
+func NewPKCE() (*PKCE, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err // notest
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(b)
+	return &PKCE{Verifier: verifier, Challenge: PKCEChallenge(verifier)}, nil
+}
+
+// PKCEChallenge returns the S256 code challenge for the given code verifier.
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,24 @@
+package descope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPKCE(t *testing.T) {
+	pkce, err := NewPKCE()
+	require.NoError(t, err)
+	assert.Len(t, pkce.Verifier, 43)
+	assert.Equal(t, PKCEChallenge(pkce.Verifier), pkce.Challenge)
+
+	other, err := NewPKCE()
+	require.NoError(t, err)
+	assert.NotEqual(t, pkce.Verifier, other.Verifier)
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// the example from RFC 7636, appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
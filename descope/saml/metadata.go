@@ -0,0 +1,210 @@
+// Package saml provides helpers for configuring SAML SSO for tenants from the
+// metadata XML files that identity providers publish.
+//
+// The XML signatures of metadata files are not checked, so metadata should only be
+// loaded from trusted sources, e.g., downloaded over HTTPS from the identity provider.
+package saml
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/sdk"
+)
+
+// SAML bindings for the SingleSignOnService endpoints of an identity provider
+const (
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+)
+
+// A single sign on endpoint of an identity provider
+type SingleSignOnService struct {
+	Binding  string
+	Location string
+}
+
+// The identity provider details parsed from a metadata file.
+type Metadata struct {
+	// The entity ID of the identity provider
+	EntityID string
+	// The URL of the single sign on endpoint for the preferred binding
+	SSOURL string
+	// The signing certificate of the identity provider in PEM format
+	Certificate string
+	// All the single sign on endpoints listed in the metadata
+	SingleSignOnServices []*SingleSignOnService
+	// The time until which the metadata is valid, or the zero time if it's not set
+	ValidUntil time.Time
+}
+
+// Options for parsing metadata, all fields are optional.
+type ParseOptions struct {
+	// Rejects metadata whose validUntil attribute is in the past
+	RequireValid bool
+	// The binding to prefer when choosing the SSOURL, defaults to BindingHTTPRedirect,
+	// with other bindings used only when the preferred one isn't available
+	Binding string
+}
+
+// ConfigureSettings configures SAML SSO for a tenant with the parsed metadata, by
+// calling the ConfigureSettings function of the SSO management API.
+func (m *Metadata) ConfigureSettings(mgmt sdk.Management, tenantID, redirectURL string) error {
+	return mgmt.SSO().ConfigureSettings(tenantID, m.SSOURL, m.Certificate, m.EntityID, redirectURL)
+}
+
+type xmlKeyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+type xmlSingleSignOnService struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type xmlIDPSSODescriptor struct {
+	KeyDescriptors       []xmlKeyDescriptor       `xml:"KeyDescriptor"`
+	SingleSignOnServices []xmlSingleSignOnService `xml:"SingleSignOnService"`
+}
+
+type xmlEntityDescriptor struct {
+	XMLName          xml.Name
+	EntityID         string                `xml:"entityID,attr"`
+	ValidUntil       string                `xml:"validUntil,attr"`
+	IDPSSODescriptor *xmlIDPSSODescriptor  `xml:"IDPSSODescriptor"`
+	Entities         []xmlEntityDescriptor `xml:"EntityDescriptor"`
+}
+
+// Parse parses the metadata XML of an identity provider. The metadata is expected
+// to have an EntityDescriptor root element, or an EntitiesDescriptor root element,
+// in which case the first entity with an IDPSSODescriptor is used. Signatures in the
+// metadata are ignored, and are not checked.
+func Parse(data []byte, options *ParseOptions) (*Metadata, error) {
+	if options == nil {
+		options = &ParseOptions{}
+	}
+	root := &xmlEntityDescriptor{}
+	if err := xml.Unmarshal(data, root); err != nil {
+		return nil, descope.ErrInvalidArguments.WithMessage("Invalid metadata XML").WithCause(err)
+	}
+
+	entity := root
+	if root.XMLName.Local == "EntitiesDescriptor" {
+		entity = nil
+		for i := range root.Entities {
+			if root.Entities[i].IDPSSODescriptor != nil {
+				entity = &root.Entities[i]
+				break
+			}
+		}
+	} else if root.XMLName.Local != "EntityDescriptor" {
+		return nil, descope.ErrInvalidArguments.WithMessage("Unexpected metadata root element %s", root.XMLName.Local)
+	}
+	if entity == nil || entity.IDPSSODescriptor == nil {
+		return nil, descope.ErrInvalidArguments.WithMessage("The metadata doesn't have an IDPSSODescriptor element")
+	}
+
+	metadata := &Metadata{EntityID: entity.EntityID}
+	if metadata.EntityID == "" {
+		return nil, descope.ErrInvalidArguments.WithMessage("The metadata doesn't have an entity ID")
+	}
+
+	validUntil, err := parseValidUntil(root.ValidUntil, entity.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+	metadata.ValidUntil = validUntil
+	if options.RequireValid && !validUntil.IsZero() && time.Now().After(validUntil) {
+		return nil, descope.ErrValidationFailure.WithMessage("The metadata expired at %s", validUntil.Format(time.RFC3339))
+	}
+
+	for _, service := range entity.IDPSSODescriptor.SingleSignOnServices {
+		metadata.SingleSignOnServices = append(metadata.SingleSignOnServices, &SingleSignOnService{Binding: service.Binding, Location: strings.TrimSpace(service.Location)})
+	}
+	if metadata.SSOURL = preferredLocation(metadata.SingleSignOnServices, options.Binding); metadata.SSOURL == "" {
+		return nil, descope.ErrInvalidArguments.WithMessage("The metadata doesn't have a SingleSignOnService location")
+	}
+
+	if metadata.Certificate, err = signingCertificate(entity.IDPSSODescriptor.KeyDescriptors); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// ParseReader reads and parses the metadata XML of an identity provider, see Parse.
+func ParseReader(r io.Reader, options *ParseOptions) (*Metadata, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, options)
+}
+
+func parseValidUntil(values ...string) (time.Time, error) {
+	var validUntil time.Time
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, descope.ErrInvalidArguments.WithMessage("Invalid validUntil attribute %s", value).WithCause(err)
+		}
+		if validUntil.IsZero() || t.Before(validUntil) {
+			validUntil = t
+		}
+	}
+	return validUntil, nil
+}
+
+func preferredLocation(services []*SingleSignOnService, binding string) string {
+	if binding == "" {
+		binding = BindingHTTPRedirect
+	}
+	for _, b := range []string{binding, BindingHTTPRedirect, BindingHTTPPost} {
+		for _, service := range services {
+			if service.Binding == b && service.Location != "" {
+				return service.Location
+			}
+		}
+	}
+	for _, service := range services {
+		if service.Location != "" {
+			return service.Location
+		}
+	}
+	return ""
+}
+
+// Returns the first signing certificate in PEM format, where key descriptors without
+// a use attribute are used for both signing and encryption
+func signingCertificate(keys []xmlKeyDescriptor) (string, error) {
+	for _, key := range keys {
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+		for _, cert := range key.Certificates {
+			encoded := strings.Join(strings.Fields(cert), "")
+			if encoded == "" {
+				continue
+			}
+			der, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return "", descope.ErrInvalidArguments.WithMessage("Invalid X509Certificate encoding").WithCause(err)
+			}
+			pemCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+			if _, err := descope.ParseIdPCertificate(pemCert); err != nil {
+				return "", err
+			}
+			return pemCert, nil
+		}
+	}
+	return "", descope.ErrInvalidArguments.WithMessage("The metadata doesn't have a signing X509Certificate")
+}
@@ -0,0 +1,149 @@
+package saml
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	mocksmgmt "github.com/descope/go-sdk/descope/tests/mocks/mgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestCertificate(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return der
+}
+
+func createTestMetadata(t *testing.T, attrs, signature string) (string, []byte) {
+	der := createTestCertificate(t)
+	metadata := fmt.Sprintf(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="http://www.okta.com/abc" %s>
+  %s
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>invalid</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+        %s
+      </ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://acme.okta.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://acme.okta.com/sso/redirect"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, attrs, signature, base64.StdEncoding.EncodeToString(der))
+	return metadata, der
+}
+
+func TestParse(t *testing.T) {
+	metadata, der := createTestMetadata(t, "", "")
+	res, err := Parse([]byte(metadata), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://www.okta.com/abc", res.EntityID)
+	assert.Equal(t, "https://acme.okta.com/sso/redirect", res.SSOURL)
+	assert.Len(t, res.SingleSignOnServices, 2)
+	assert.Equal(t, BindingHTTPPost, res.SingleSignOnServices[0].Binding)
+	assert.Equal(t, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), res.Certificate)
+	assert.True(t, res.ValidUntil.IsZero())
+
+	res, err = Parse([]byte(metadata), &ParseOptions{Binding: BindingHTTPPost})
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.okta.com/sso/post", res.SSOURL)
+
+	res, err = ParseReader(strings.NewReader(metadata), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://www.okta.com/abc", res.EntityID)
+}
+
+func TestParseEntitiesDescriptor(t *testing.T) {
+	metadata, _ := createTestMetadata(t, "", "")
+	metadata = strings.Replace(metadata, `<?xml version="1.0"?>`, `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata"><EntityDescriptor entityID="sp"><SPSSODescriptor/></EntityDescriptor>`, 1) + "</EntitiesDescriptor>"
+	res, err := Parse([]byte(metadata), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://www.okta.com/abc", res.EntityID)
+
+	_, err = Parse([]byte(`<EntitiesDescriptor><EntityDescriptor entityID="sp"/></EntitiesDescriptor>`), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestParseValidity(t *testing.T) {
+	expired := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	metadata, _ := createTestMetadata(t, fmt.Sprintf(`validUntil="%s"`, expired.Format(time.RFC3339)), "")
+	res, err := Parse([]byte(metadata), nil)
+	require.NoError(t, err)
+	assert.Equal(t, expired, res.ValidUntil)
+	_, err = Parse([]byte(metadata), &ParseOptions{RequireValid: true})
+	assert.ErrorIs(t, err, descope.ErrValidationFailure)
+
+	valid := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	metadata, _ = createTestMetadata(t, fmt.Sprintf(`validUntil="%s"`, valid.Format(time.RFC3339)), "")
+	res, err = Parse([]byte(metadata), &ParseOptions{RequireValid: true})
+	require.NoError(t, err)
+	assert.Equal(t, valid, res.ValidUntil)
+
+	metadata, _ = createTestMetadata(t, `validUntil="tomorrow"`, "")
+	_, err = Parse([]byte(metadata), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestParseIgnoresSignature(t *testing.T) {
+	// signatures aren't checked, so an invalid one doesn't fail parsing
+	metadata, _ := createTestMetadata(t, "", `<ds:Signature><ds:SignedInfo/><ds:SignatureValue>invalid</ds:SignatureValue></ds:Signature>`)
+	res, err := Parse([]byte(metadata), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://www.okta.com/abc", res.EntityID)
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse([]byte("not xml"), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = Parse([]byte(`<Foo/>`), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = Parse([]byte(`<EntityDescriptor entityID="abc"/>`), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = Parse([]byte(`<EntityDescriptor><IDPSSODescriptor/></EntityDescriptor>`), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	_, err = Parse([]byte(`<EntityDescriptor entityID="abc"><IDPSSODescriptor/></EntityDescriptor>`), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+
+	metadata, _ := createTestMetadata(t, "", "")
+	noCert := strings.Replace(metadata, `use="signing"`, `use="encryption"`, 1)
+	_, err = Parse([]byte(noCert), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+	badCert := strings.Replace(metadata, `use="encryption"`, `use="signing"`, 1)
+	_, err = Parse([]byte(badCert), nil)
+	assert.ErrorIs(t, err, descope.ErrInvalidArguments)
+}
+
+func TestConfigureSettings(t *testing.T) {
+	metadata, _ := createTestMetadata(t, "", "")
+	res, err := Parse([]byte(metadata), nil)
+	require.NoError(t, err)
+	called := false
+	mgmt := &mocksmgmt.MockManagement{MockSSO: &mocksmgmt.MockSSO{
+		ConfigureSettingsAssert: func(tenantID, idpURL, idpCert, entityID, redirectURL string) {
+			called = true
+			assert.Equal(t, "t1", tenantID)
+			assert.Equal(t, res.SSOURL, idpURL)
+			assert.Equal(t, res.Certificate, idpCert)
+			assert.Equal(t, res.EntityID, entityID)
+			assert.Equal(t, "https://my-app.com/handle-saml", redirectURL)
+		},
+	}}
+	require.NoError(t, res.ConfigureSettings(mgmt, "t1", "https://my-app.com/handle-saml"))
+	assert.True(t, called)
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/descope/go-sdk/descope"
 )
@@ -124,16 +125,106 @@ type TOTP interface {
 	UpdateUser(loginID string, request *http.Request) (*descope.TOTPResponse, error)
 }
 
+type Password interface {
+	// SignUp - Use to create a new user that authenticates with a password.
+	// The password must meet the project's password policy, see GetPolicy.
+	// Use the ResponseWriter (optional) to apply the cookies to the response automatically.
+	// returns the authentication info of the new user or an error upon failure.
+	SignUp(loginID string, user *descope.User, password string, w http.ResponseWriter) (*descope.AuthenticationInfo, error)
+
+	// SignIn - Use to authenticate an existing user with the given loginID and password.
+	// The loginOptions (optional) can be used for step-up or MFA, in which case the request
+	// must contain a valid refresh token.
+	// Use the ResponseWriter (optional) to apply the cookies to the response automatically.
+	// returns the authentication info or an error upon failure.
+	SignIn(loginID, password string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (*descope.AuthenticationInfo, error)
+
+	// SendPasswordReset - Use to send a password reset email to the user with the given loginID.
+	// The redirectURL (optional) is where the user is sent after clicking the link in the email,
+	// and should eventually call UpdatePassword with the new password.
+	// returns an error upon failure.
+	SendPasswordReset(loginID, redirectURL string) error
+
+	// UpdatePassword - Use to set a new password for a user, e.g., after a password reset.
+	// The request must contain a valid refresh token of the user.
+	// returns an error upon failure.
+	UpdatePassword(loginID, newPassword string, r *http.Request) error
+
+	// ReplacePassword - Use to replace the password of a user that knows their current password.
+	// The user is also signed in after the password is replaced.
+	// Use the ResponseWriter (optional) to apply the cookies to the response automatically.
+	// returns the authentication info or an error upon failure.
+	ReplacePassword(loginID, oldPassword, newPassword string, w http.ResponseWriter) (*descope.AuthenticationInfo, error)
+
+	// GetPolicy - Use to load the password policy configured for the project, e.g., to
+	// show the password requirements in a sign up form.
+	// returns the password policy or an error upon failure.
+	GetPolicy() (*descope.PasswordPolicy, error)
+}
+
+// Runs Descope flows without the web components, e.g., from native apps or command
+// line clients. Each response describes the current step of the flow, and the client
+// performs it and then calls Next until the flow is completed.
+type Flow interface {
+	// Start - Use to start executing the flow with the given flowID.
+	// The input (optional) is made available to the flow, e.g., to prefill the login ID.
+	// Use the ResponseWriter (optional) to apply the cookies to the response automatically
+	// when the flow completes immediately.
+	// returns the first step of the flow or an error upon failure.
+	Start(flowID string, input map[string]any, w http.ResponseWriter) (*descope.FlowResponse, error)
+
+	// Next - Use to continue a flow execution after the client performed the current step.
+	// The executionID and stepID are taken from the previous response, and the interactionID
+	// identifies what the user did on a screen, e.g., which button they clicked. The input
+	// (optional) holds the values the user entered, or the result of a WebAuthn ceremony.
+	// When the flow completes the response contains the AuthInfo of the authenticated user.
+	// Use the ResponseWriter (optional) to apply the cookies to the response automatically.
+	// returns the next step of the flow or an error upon failure.
+	Next(executionID, stepID, interactionID string, input map[string]any, w http.ResponseWriter) (*descope.FlowResponse, error)
+}
+
 type OAuth interface {
-	// Start - Use to start an OAuth authentication using the given OAuthProvider.
+	// Start - Use to start an OAuth authentication using the given OAuthProvider, which is
+	// either one of the built-in providers or the name of a custom provider configured in the console.
 	// returns an error upon failure and a string represent the redirect URL upon success.
 	// Uses the response writer to automatically redirect the client to the provider url for authentication.
 	// A successful authentication will result in a callback to the url defined in the current project settings.
 	Start(provider descope.OAuthProvider, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (string, error)
 
+	// StartWithOptions - Use to start an OAuth authentication the same as Start, with additional
+	// options such as a PKCE code challenge, or the scopes, prompt and login hint sent to the provider.
+	//
+	// When OAuth state protection is enabled in the client configuration a random state is
+	// created and added to the returnURL, which is then required. The state is bound to the
+	// user's browser with a cookie using the ResponseWriter, or to the code challenge when
+	// PKCE is used, and it's also saved in the OAuthStateStore if one is configured.
+	StartWithOptions(provider descope.OAuthProvider, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, options *descope.OAuthOptions, w http.ResponseWriter) (string, error)
+
 	// ExchangeToken - Finalize OAuth
 	// code should be extracted from the redirect URL of OAth/SAML authentication flow
+	// When OAuth state protection is enabled use ExchangeTokenWithOptions instead, as the
+	// state can't be verified without the callback request.
 	ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error)
+
+	// ExchangeTokenWithOptions - Finalize OAuth the same as ExchangeToken, with additional
+	// options such as a PKCE code verifier.
+	//
+	// When OAuth state protection is enabled, the state in the query of the callback request r
+	// must be one created by StartWithOptions, and each state can only be used once. If the
+	// authentication was started with PKCE the code verifier must match the code challenge in
+	// the state, otherwise the state must match the cookie in the callback request.
+	ExchangeTokenWithOptions(code string, r *http.Request, options *descope.OAuthExchangeOptions, w http.ResponseWriter) (*descope.AuthenticationInfo, error)
+}
+
+// Stores the states created when starting OAuth authentications, so that they can be
+// verified when the code is exchanged. Implementations must be safe for concurrent use,
+// and should be shared by all the instances of the application, e.g., by using Redis.
+type OAuthStateStore interface {
+	// Save the state until the expiration time.
+	Save(state string, expiration time.Time) error
+
+	// Remove the state, and return whether it was saved and hasn't expired yet.
+	Consume(state string) (bool, error)
 }
 
 type SAML interface {
@@ -147,6 +238,28 @@ type SAML interface {
 	ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error)
 }
 
+// Provides SSO authentication for tenants, using either SAML or OIDC according to
+// the SSO settings that are configured for each tenant.
+type SSOServiceProvider interface {
+	// Start will initiate an SSO login flow for the given tenant, using the protocol
+	// that's configured for it.
+	// return will be the redirect URL that needs to return to client
+	// and finalize with the ExchangeToken call
+	Start(tenant string, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (redirectURL string, err error)
+
+	// StartByEmail will initiate an SSO login flow for the tenant whose self provisioning
+	// domains match the domain of the given email, e.g., for a "Continue with SSO" button.
+	// The tenant is resolved by the Descope service, or locally when the client is configured
+	// with an SSOTenantsCacheTTL and a management key.
+	// returns descope.ErrSSOTenantNotFound when no tenant matches the domain and
+	// descope.ErrSSOMultipleTenants when there's more than one.
+	StartByEmail(email string, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (redirectURL string, err error)
+
+	// ExchangeToken - Finalize SSO authentication
+	// code should be extracted from the redirect URL of the SSO authentication flow
+	ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error)
+}
+
 type WebAuthn interface {
 	// SignUpStart - Use to start an authentication process with webauthn for the new user argument.
 	// Origin is the origin of the URL for the web page where the webauthn operation is taking place, as returned
@@ -196,8 +309,11 @@ type Authentication interface {
 	EnchantedLink() EnchantedLink
 	OTP() OTP
 	TOTP() TOTP
+	Password() Password
+	Flow() Flow
 	OAuth() OAuth
 	SAML() SAML
+	SSO() SSOServiceProvider
 	WebAuthn() WebAuthn
 
 	// ValidateSessionWithRequest - Use to validate a session of a given request.
@@ -1,6 +1,11 @@
 package sdk
 
-import "github.com/descope/go-sdk/descope"
+import (
+	"io"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+)
 
 // Provides functions for managing tenants in a project.
 type Tenant interface {
@@ -21,19 +26,51 @@ type Tenant interface {
 	// Both the name and ID must be unique per project.
 	CreateWithID(id, name string, selfProvisioningDomains []string) error
 
+	// Create a new tenant with the fields set in the tenant parameter, which can also set
+	// the tenant's custom attributes.
+	//
+	// The id is optional and is generated automatically when empty. The generated or given
+	// ID is returned. The tenant name is required and must be unique per project.
+	CreateWithOptions(id string, tenant *descope.TenantRequest) (string, error)
+
 	// Update an existing tenant's name and domains.
 	//
 	// IMPORTANT: All parameters are required and will override whatever value is currently
 	// set in the existing tenant. Use carefully.
 	Update(id, name string, selfProvisioningDomains []string) error
 
+	// Update an existing tenant with the fields set in the tenant parameter.
+	//
+	// IMPORTANT: The name and domains will override whatever value is currently set in the
+	// existing tenant. The custom attributes are only overridden when they're not nil. Use carefully.
+	UpdateWithOptions(id string, tenant *descope.TenantRequest) error
+
 	// Delete an existing tenant.
 	//
 	// IMPORTANT: This action is irreversible. Use carefully.
 	Delete(id string) error
 
+	// Load a tenant by its ID.
+	Load(id string) (*descope.Tenant, error)
+
 	// Load all project tenants
 	LoadAll() ([]*descope.Tenant, error)
+
+	// Search all tenants according to given filters
+	//
+	// The options optional parameter allows to filter the tenants by their IDs, names,
+	// self provisioning domains or custom attributes. When nil all tenants are returned.
+	SearchAll(options *descope.TenantSearchOptions) ([]*descope.Tenant, error)
+
+	// Load the settings of a tenant by its ID.
+	GetSettings(id string) (*descope.TenantSettings, error)
+
+	// Configure the settings of a tenant, such as session timeouts, enabled authentication
+	// methods and domain enforcement.
+	//
+	// Only the settings that are set are changed, and all other settings are left as they
+	// are. Use descope.Ptr to set the optional fields.
+	ConfigureSettings(id string, settings *descope.TenantSettings) error
 }
 
 // Provides functions for managing users in a project.
@@ -49,14 +86,90 @@ type User interface {
 	// user has in each one.
 	Create(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant) (*descope.UserResponse, error)
 
+	// Create a new user with values for its custom attributes.
+	//
+	// The parameters follow the same convention as those for the Create function.
+	//
+	// The customAttributes parameter is an optional map of values for the custom
+	// user attributes defined in the project, e.g., a department or employee ID.
+	CreateWithCustomAttributes(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any) (*descope.UserResponse, error)
+
 	// Update an existing user.
 	//
 	// The parameters follow the same convention as those for the Create function.
 	//
 	// IMPORTANT: All parameters will override whatever values are currently set
 	// in the existing user. Use carefully.
+	//
+	// The user's custom attributes aren't changed, use UpdateWithCustomAttributes or
+	// UpdateCustomAttribute to change them.
 	Update(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant) (*descope.UserResponse, error)
 
+	// Update an existing user along with its custom attributes.
+	//
+	// The parameters follow the same convention as those for the CreateWithCustomAttributes
+	// function.
+	//
+	// IMPORTANT: All parameters will override whatever values are currently set
+	// in the existing user, except for customAttributes which are only overridden
+	// when not nil. Use carefully.
+	UpdateWithCustomAttributes(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any) (*descope.UserResponse, error)
+
+	// Create a new user with the fields set in the user parameter.
+	//
+	// The loginID is required and will determine what the user will use to
+	// sign in. The user parameter is optional, and any fields that aren't set
+	// in it are left empty. Unlike Create, it can also set the verified flags,
+	// picture, status and additional login IDs of the user.
+	CreateWithOptions(loginID string, user *descope.UserRequest) (*descope.UserResponse, error)
+
+	// Patch an existing user, changing only the fields that are set in the user
+	// parameter and leaving all other fields as they are.
+	Patch(loginID string, user *descope.UserRequest) (*descope.UserResponse, error)
+
+	// Create a new test user, for use in end-to-end tests.
+	//
+	// The parameters follow the same convention as those for the CreateWithOptions
+	// function. Test users can sign in without sending real emails or text messages
+	// by using the GenerateXForTestUser functions, and can all be deleted at once
+	// with DeleteAllTestUsers.
+	CreateTestUser(loginID string, user *descope.UserRequest) (*descope.UserResponse, error)
+
+	// Generate a one time code for a test user, as if it was sent with the given
+	// delivery method, and return it instead of sending it.
+	//
+	// The code can then be verified with the OTP VerifyCode function.
+	GenerateOTPForTestUser(method descope.DeliveryMethod, loginID string) (code string, err error)
+
+	// Generate a magic link for a test user, as if it was sent with the given
+	// delivery method, and return it instead of sending it.
+	//
+	// The URI parameter is optional and overrides the redirect URL configured in the project.
+	GenerateMagicLinkForTestUser(method descope.DeliveryMethod, loginID, URI string) (link string, err error)
+
+	// Generate an enchanted link for a test user and return it instead of sending it,
+	// along with the pending reference that can be polled for the session.
+	//
+	// The URI parameter is optional and overrides the redirect URL configured in the project.
+	GenerateEnchantedLinkForTestUser(loginID, URI string) (link, pendingRef string, err error)
+
+	// Impersonate another user, e.g., to allow support staff to reproduce an issue
+	// from the user's point of view.
+	//
+	// The impersonatorID is the user ID of the user performing the impersonation, who
+	// must have the impersonation permission. The loginID is the user to impersonate.
+	// When validateConsent is true the impersonation only succeeds if the impersonated
+	// user has given their consent.
+	//
+	// Returns a refresh JWT for the impersonated user, in which the impersonator is
+	// recorded and can be read with the Impersonator function of descope.Token.
+	Impersonate(impersonatorID, loginID string, validateConsent bool) (string, error)
+
+	// Delete all the test users in the project.
+	//
+	// IMPORTANT: This action is irreversible. Use carefully.
+	DeleteAllTestUsers() error
+
 	// Delete an existing user.
 	//
 	// IMPORTANT: This action is irreversible. Use carefully.
@@ -83,6 +196,40 @@ type User interface {
 	// default amount.
 	SearchAll(tenantIDs, roles []string, limit int32) ([]*descope.UserResponse, error)
 
+	// Search users according to the given options, returning a single page of results.
+	//
+	// The options parameter is optional, and when nil the first page of users is
+	// returned with the default limit.
+	SearchAllWithOptions(options *descope.UserSearchOptions) ([]*descope.UserResponse, error)
+
+	// Returns an iterator that walks over all the users matching the given options,
+	// loading them one page at a time as needed.
+	//
+	// The Page field in the options sets the first page to load, and the Limit field
+	// the size of each page. The iterator stops after a page with fewer users than
+	// the limit, or at the first error, which is then available by calling Err.
+	//
+	//	it := descopeClient.Management.User().SearchIterator(&descope.UserSearchOptions{TenantIDs: []string{"t1"}})
+	//	for it.Next() {
+	//		user := it.User()
+	//	}
+	//	if err := it.Err(); err != nil {
+	//		// handle error
+	//	}
+	SearchIterator(options *descope.UserSearchOptions) UserIterator
+
+	// Performs many user operations concurrently, e.g., when migrating users into
+	// the project or between tenants.
+	//
+	// Each item is performed independently and the returned results are in the same
+	// order as the items, with an error set on each operation that failed. Operations
+	// that fail due to rate limits are retried after waiting as requested by the server,
+	// and all workers pause during that time.
+	//
+	// The options parameter is optional. The returned error is only set when the
+	// batch can't be started, or when the checkpoint file can't be read or written.
+	Batch(items []*descope.UserBatchItem, options *descope.UserBatchOptions) ([]*descope.UserBatchResult, error)
+
 	// Activate an existing user.
 	Activate(loginID string) (*descope.UserResponse, error)
 
@@ -110,6 +257,12 @@ type User interface {
 	// The displayName parameter can be empty in which case the name will be removed.
 	UpdateDisplayName(loginID, displayName string) (*descope.UserResponse, error)
 
+	// Update a single custom attribute of an existing user.
+	//
+	// The key must be the name of a custom user attribute defined in the project,
+	// and the value must match its type. A nil value removes the attribute.
+	UpdateCustomAttribute(loginID, key string, value any) (*descope.UserResponse, error)
+
 	// Add roles for a user without tenant association. Use AddTenantRoles for users
 	// that are part of a multi-tenant project.
 	AddRoles(loginID string, roles []string) (*descope.UserResponse, error)
@@ -129,6 +282,28 @@ type User interface {
 
 	// Remove roles from a user in a specific tenant.
 	RemoveTenantRoles(loginID string, tenantID string, roles []string) (*descope.UserResponse, error)
+
+	// Set a password for an existing user, e.g., when migrating users with known passwords.
+	//
+	// The password is set as expired, so the user must replace it the next time they sign in.
+	SetPassword(loginID string, password string) error
+
+	// Expire the password of an existing user, so that the user must replace it or
+	// reset it before signing in again.
+	ExpirePassword(loginID string) error
+}
+
+// Iterates over the users returned by a search, see User.SearchIterator.
+type UserIterator interface {
+	// Advances to the next user, loading the next page of results if needed. Returns
+	// false when there are no more users or an error occurred.
+	Next() bool
+
+	// The current user, valid after a call to Next returns true.
+	User() *descope.UserResponse
+
+	// The error that stopped the iteration, if any.
+	Err() error
 }
 
 // Provides functions for managing access keys in a project.
@@ -148,6 +323,14 @@ type AccessKey interface {
 	// access key has in each one.
 	Create(name string, expireTime int64, roles []string, keyTenants []*descope.AssociatedTenant) (string, *descope.AccessKeyResponse, error)
 
+	// Create a new access key with the fields set in the key parameter, which can also
+	// set the key's description, custom claims and permitted IP addresses.
+	// IMPORTANT: The access key cleartext will be returned only when first created.
+	// 			  Make sure to save it in a secure manner.
+	//
+	// The key's name is required and follows the same convention as the Create function.
+	CreateWithOptions(key *descope.AccessKeyRequest) (string, *descope.AccessKeyResponse, error)
+
 	// Load an existing access key.
 	//
 	// The id parameter is required and the access key will be fetched according to it.
@@ -161,12 +344,19 @@ type AccessKey interface {
 	// Update an existing access key.
 	//
 	// The parameters follow the same convention as those for the Create function.
-	// Only the name is settable for the time being.
+	// Only the name is settable with this function, see UpdateWithOptions for the others.
 	//
 	// IMPORTANT: All parameters will override whatever values are currently set
 	// in the existing access key. Use carefully.
 	Update(id, name string) (*descope.AccessKeyResponse, error)
 
+	// Update an existing access key with the fields set in the key parameter.
+	//
+	// The name is required and overrides the current name. All other fields are only
+	// overridden when they're not nil, and can be cleared by setting empty values. The
+	// expiration time can't be changed and is ignored.
+	UpdateWithOptions(id string, key *descope.AccessKeyRequest) (*descope.AccessKeyResponse, error)
+
 	// Deactivate an existing access key.
 	//
 	// IMPORTANT: This deactivated key will not be usable from this stage. It will, however,
@@ -183,6 +373,20 @@ type AccessKey interface {
 	//
 	// IMPORTANT: This action is irreversible. Use carefully.
 	Delete(id string) error
+
+	// Rotate an existing access key without downtime, by creating a new access key with
+	// the same name, description, roles, tenants, custom claims and permitted IPs, and deactivating the old one after the grace period.
+	// The new key gets the same lifetime as the old one, or doesn't expire if the old one
+	// doesn't expire.
+	//
+	// IMPORTANT: The cleartext of the new access key is returned only once. Make sure to
+	// save it in a secure manner and distribute it before the grace period ends.
+	//
+	// When the grace period is 0 the old key is deactivated immediately. Otherwise the old
+	// key must be deactivated by the caller after the returned DeactivateAt time, either
+	// with Deactivate or with RotationPlanner.DeactivateRotated. The rotation isn't recorded
+	// on the access keys, so keep the returned rotation until the old key is deactivated.
+	Rotate(id string, gracePeriod time.Duration) (*descope.AccessKeyRotation, error)
 }
 
 // Provides functions for configuring SSO for a project.
@@ -198,6 +402,23 @@ type SSO interface {
 
 	// Configure SSO IDP mapping including groups to the Descope roles and user attributes.
 	ConfigureMapping(tenantID string, roleMappings []*descope.RoleMapping, attributeMapping *descope.AttributeMapping) error
+
+	// Configure SSO setting for a tenant with an OIDC identity provider, such as Okta or
+	// Azure AD, instead of SAML.
+	//
+	// The settings must include the client ID and either the issuer or the discovery URL.
+	ConfigureOIDCSettings(tenantID string, settings *descope.OIDCSettings) error
+
+	// Load the SSO settings of a tenant, including its identity provider details and
+	// mappings. The expiry of the IdP certificate is parsed from the certificate when
+	// it's set, and can be checked to alert before it expires.
+	LoadSettings(tenantID string) (*descope.SSOSettingsResponse, error)
+
+	// Delete the SSO settings of a tenant, after which its users can no longer sign in
+	// with SSO.
+	//
+	// IMPORTANT: This action is irreversible. Use carefully.
+	DeleteSettings(tenantID string) error
 }
 
 // Provide functions for manipulating valid JWT
@@ -279,6 +500,124 @@ type Group interface {
 
 	// Load all members of the provided group id.
 	LoadAllGroupMembers(tenantID, groupID string) ([]*descope.Group, error)
+
+	// Create a new group in the given tenant.
+	//
+	// The groupID and display name are required. The optional userIDs are added
+	// as the initial members of the group.
+	Create(tenantID, groupID, display string, userIDs []string) error
+
+	// Update the display name of an existing group.
+	//
+	// IMPORTANT: The display name will be overridden, so it must be provided.
+	Update(tenantID, groupID, display string) error
+
+	// Delete an existing group.
+	//
+	// IMPORTANT: This action is irreversible. Use carefully.
+	Delete(tenantID, groupID string) error
+
+	// Add members to an existing group, identified either by user IDs or login IDs.
+	AddMembers(tenantID, groupID string, userIDs, loginIDs []string) error
+
+	// Remove members from an existing group, identified either by user IDs or login IDs.
+	RemoveMembers(tenantID, groupID string, userIDs, loginIDs []string) error
+
+	// Make the groups of a tenant match the desired state.
+	//
+	// The current groups are loaded with LoadAllGroups and compared by group ID. Groups
+	// that don't exist are created, groups that aren't in desired are deleted, groups
+	// whose display name differs are updated, and members are added or removed as needed.
+	// Desired members are matched by their UserID, or by their LoginID when no UserID
+	// is set. Groups and members that already match are left untouched.
+	//
+	// The changes are applied in order and syncing stops at the first error, in which
+	// case the returned result lists the changes that were already made.
+	SyncGroups(tenantID string, desired []descope.Group) (*descope.GroupSyncResult, error)
+}
+
+// Provides functions for reading the project's audit log.
+type Audit interface {
+	// Returns an iterator that walks over all the audit records matching the given
+	// options, loading them one page at a time and following the continuation
+	// returned by the server until there are no more records.
+	//
+	// The options parameter is optional, and when nil all records are returned. The
+	// iterator stops at the first error, which is then available by calling Err.
+	//
+	//	it := descopeClient.Management.Audit().Search(&descope.AuditSearchOptions{Actions: []string{"LoginFailed"}})
+	//	for it.Next() {
+	//		record := it.Record()
+	//	}
+	//	if err := it.Err(); err != nil {
+	//		// handle error
+	//	}
+	Search(options *descope.AuditSearchOptions) AuditIterator
+
+	// Writes all the audit records matching the given options to w in JSONL format,
+	// with one JSON object per line. Returns the number of records written, which is
+	// also set when an error occurs.
+	Export(w io.Writer, options *descope.AuditSearchOptions) (int, error)
+}
+
+// Iterates over the records returned by an audit search, see Audit.Search.
+type AuditIterator interface {
+	// Advances to the next record, loading the next page of results if needed. Returns
+	// false when there are no more records or an error occurred.
+	Next() bool
+
+	// The current record, valid after a call to Next returns true.
+	Record() *descope.AuditRecord
+
+	// The error that stopped the iteration, if any.
+	Err() error
+}
+
+// Provides functions for exporting and importing flows and themes, e.g., to keep them
+// in source control and promote them between projects.
+type FlowManagement interface {
+	// Load the details of all the flows in the project.
+	ListFlows() ([]*descope.FlowMetadata, error)
+
+	// Export the definition of the flow with the given ID, including its screens.
+	ExportFlow(flowID string) (map[string]any, error)
+
+	// Import a flow definition in the format returned by ExportFlow, creating the flow
+	// with the given ID or replacing it if it already exists.
+	//
+	// IMPORTANT: The flow will be overridden, so its current definition is lost.
+	ImportFlow(flowID string, flowJSON map[string]any) error
+
+	// Export the project's theme, which styles the screens of all flows.
+	ExportTheme() (map[string]any, error)
+
+	// Import a theme in the format returned by ExportTheme, replacing the project's theme.
+	ImportTheme(theme map[string]any) error
+}
+
+// Provides functions for copying the configuration of a project.
+type Project interface {
+	// Export a snapshot of the project's roles, permissions, tenants, their SSO settings and
+	// the project's flows, which are loaded with the other management APIs. OIDC client secrets are removed from
+	// the snapshot so that it can be safely committed to source control.
+	Export() (*descope.ProjectSnapshot, error)
+
+	// Import a snapshot created by Export into the project, and return the changes that
+	// were made. The permissions, roles and tenants are planned and applied the same way as
+	// with the config package, after which the SSO settings and flows in the snapshot that
+	// differ from the project's current ones are configured and imported.
+	//
+	// OIDC client secrets that are empty in the snapshot, as they are after Export, are left
+	// out when configuring the SSO settings, so the project keeps its current client secrets.
+	//
+	// The options parameter is optional. When DryRun is set the snapshot is only
+	// validated and the changes that would be made are returned without applying them.
+	//
+	// Entities that aren't in the snapshot are kept, unless the Prune option is set.
+	//
+	// IMPORTANT: With Prune, permissions, roles and tenants that aren't in the snapshot are
+	// deleted, unless that whole section is missing from it. Use carefully.
+	Import(snapshot *descope.ProjectSnapshot, options *descope.ProjectImportOptions) ([]*descope.ProjectImportChange, error)
 }
 
 // Provides various APIs for managing a Descope project programmatically. A management key must
@@ -306,6 +645,15 @@ type Management interface {
 	// Provide functions for managing roles in a project
 	Role() Role
 
-	// Provide functions for querying SSO groups in a project
+	// Provide functions for managing SSO groups in a project
 	Group() Group
+
+	// Provide functions for reading the audit log of a project
+	Audit() Audit
+
+	// Provide functions for exporting and importing the configuration of a project
+	Project() Project
+
+	// Provide functions for exporting and importing flows and themes
+	Flow() FlowManagement
 }
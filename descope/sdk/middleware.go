@@ -36,3 +36,23 @@ func AuthenticationMiddleware(auth Authentication, onFailure func(http.ResponseW
 		})
 	}
 }
+
+// DenyImpersonationMiddleware - middleware used to validate session like AuthenticationMiddleware, that
+// also rejects sessions created by impersonating a user, e.g., to protect sensitive routes from support staff.
+// onFailure will be called when the authentication failed, if empty, will write unauthorized (401) on the response writer.
+// onImpersonated will be called when the session is impersonated, if empty, will write forbidden (403) on the response writer.
+func DenyImpersonationMiddleware(auth Authentication, onFailure func(http.ResponseWriter, *http.Request, error), onImpersonated func(http.ResponseWriter, *http.Request, *descope.Token)) func(next http.Handler) http.Handler {
+	return AuthenticationMiddleware(auth, onFailure, func(w http.ResponseWriter, r *http.Request, next http.Handler, token *descope.Token) {
+		if token.IsImpersonated() {
+			logger.LogInfo("Request denied because the session of user [%s] is impersonated by [%s]", token.ID, token.Impersonator())
+			if onImpersonated != nil {
+				onImpersonated(w, r, token)
+			} else {
+				w.WriteHeader(http.StatusForbidden)
+			}
+			return
+		}
+		newCtx := context.WithValue(r.Context(), descope.ContextUserIDPropertyKey, token.ID)
+		next.ServeHTTP(w, r.WithContext(newCtx))
+	})
+}
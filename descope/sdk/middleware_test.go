@@ -0,0 +1,59 @@
+package sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/sdk"
+	mocksauth "github.com/descope/go-sdk/descope/tests/mocks/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(handler func(next http.Handler) http.Handler) (*httptest.ResponseRecorder, string) {
+	userID := ""
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ = r.Context().Value(descope.ContextUserIDPropertyKey).(string)
+	})
+	w := httptest.NewRecorder()
+	handler(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	return w, userID
+}
+
+func TestAuthenticationMiddleware(t *testing.T) {
+	auth := &mocksauth.MockAuthentication{MockSession: mocksauth.MockSession{ValidateAndRefreshSessionResponse: &descope.Token{ID: "u1"}}}
+	w, userID := serve(sdk.AuthenticationMiddleware(auth, nil, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "u1", userID)
+
+	auth.ValidateAndRefreshSessionResponseFailure = true
+	w, userID = serve(sdk.AuthenticationMiddleware(auth, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, userID)
+}
+
+func TestDenyImpersonationMiddleware(t *testing.T) {
+	token := &descope.Token{ID: "u1", Claims: map[string]any{}}
+	auth := &mocksauth.MockAuthentication{MockSession: mocksauth.MockSession{ValidateAndRefreshSessionResponse: token}}
+	w, userID := serve(sdk.DenyImpersonationMiddleware(auth, nil, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "u1", userID)
+
+	token.Claims[descope.ClaimActor] = map[string]any{"sub": "support"}
+	w, userID = serve(sdk.DenyImpersonationMiddleware(auth, nil, nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, userID)
+
+	var impersonated *descope.Token
+	w, _ = serve(sdk.DenyImpersonationMiddleware(auth, nil, func(w http.ResponseWriter, _ *http.Request, token *descope.Token) {
+		impersonated = token
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+	assert.Equal(t, token, impersonated)
+
+	auth.ValidateAndRefreshSessionResponseFailure = true
+	w, _ = serve(sdk.DenyImpersonationMiddleware(auth, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
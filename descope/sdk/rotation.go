@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"sort"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+)
+
+// RotationPlanner finds access keys that are about to expire so they can be rotated
+// ahead of time, e.g., from a periodic job.
+type RotationPlanner struct {
+	// The access key management functions, e.g., descopeClient.Management.AccessKey()
+	AccessKeys AccessKey
+	// Active access keys that expire within this duration from now are due for rotation
+	Window time.Duration
+	// An optional list of tenant IDs to limit the search to
+	TenantIDs []string
+	// The grace period passed to Rotate for each key, see the Rotate function
+	GracePeriod time.Duration
+	// The rotations whose old access keys weren't deactivated yet. Rotate adds to it and
+	// DeactivateRotated removes from it, and since the rotations aren't recorded on the
+	// access keys the caller should store it between runs, e.g., as JSON.
+	Pending []*descope.AccessKeyRotation
+}
+
+// Plan returns the active access keys that expire within the window, ordered by their
+// expiration time. Access keys that don't expire are never returned, and neither are
+// access keys in Pending rotations, which are only active during their grace period.
+func (p *RotationPlanner) Plan() ([]*descope.AccessKeyResponse, error) {
+	keys, err := p.AccessKeys.SearchAll(p.TenantIDs)
+	if err != nil {
+		return nil, err
+	}
+	rotated := map[string]bool{}
+	for _, rotation := range p.Pending {
+		if rotation.OldKey != nil {
+			rotated[rotation.OldKey.ID] = true
+		}
+	}
+	deadline := time.Now().Add(p.Window).Unix()
+	due := []*descope.AccessKeyResponse{}
+	for _, key := range keys {
+		if key.ExpireTime == 0 || key.Status == "inactive" || rotated[key.ID] {
+			continue
+		}
+		if key.ExpireTime <= deadline {
+			due = append(due, key)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].ExpireTime < due[j].ExpireTime
+	})
+	return due, nil
+}
+
+// Rotate rotates the given access keys, usually the ones returned by Plan, and returns
+// the rotation of each one. Rotations with a grace period are added to Pending. It stops
+// at the first error, in which case the rotations that were completed until then are
+// returned with the error.
+func (p *RotationPlanner) Rotate(keys []*descope.AccessKeyResponse) ([]*descope.AccessKeyRotation, error) {
+	rotations := []*descope.AccessKeyRotation{}
+	for _, key := range keys {
+		rotation, err := p.AccessKeys.Rotate(key.ID, p.GracePeriod)
+		if err != nil {
+			return rotations, err
+		}
+		rotations = append(rotations, rotation)
+		if p.GracePeriod > 0 {
+			p.Pending = append(p.Pending, rotation)
+		}
+	}
+	return rotations, nil
+}
+
+// DeactivateRotated deactivates the old access keys of the Pending rotations whose grace
+// period has passed, removes those rotations from Pending and returns the IDs of the
+// deactivated access keys. It should be called periodically, e.g., from the same job that
+// calls Plan and Rotate. It stops at the first error, in which case the IDs of the access
+// keys that were deactivated until then are returned with the error.
+func (p *RotationPlanner) DeactivateRotated() ([]string, error) {
+	now := time.Now()
+	deactivated := []string{}
+	pending := []*descope.AccessKeyRotation{}
+	for i, rotation := range p.Pending {
+		if rotation.OldKey == nil || rotation.DeactivateAt.After(now) {
+			pending = append(pending, rotation)
+			continue
+		}
+		if err := p.AccessKeys.Deactivate(rotation.OldKey.ID); err != nil {
+			p.Pending = append(pending, p.Pending[i:]...)
+			return deactivated, err
+		}
+		deactivated = append(deactivated, rotation.OldKey.ID)
+	}
+	p.Pending = pending
+	return deactivated, nil
+}
@@ -0,0 +1,103 @@
+package sdk_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/descope/go-sdk/descope"
+	"github.com/descope/go-sdk/descope/sdk"
+	mocksmgmt "github.com/descope/go-sdk/descope/tests/mocks/mgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotationPlannerPlan(t *testing.T) {
+	now := time.Now().Unix()
+	keys := []*descope.AccessKeyResponse{
+		{ID: "never", Status: "active"},
+		{ID: "later", Status: "active", ExpireTime: now + 30*24*3600},
+		{ID: "soon", Status: "active", ExpireTime: now + 3600},
+		{ID: "expired", Status: "active", ExpireTime: now - 3600},
+		{ID: "inactive", Status: "inactive", ExpireTime: now + 3600},
+		{ID: "rotated", Status: "active", ExpireTime: now + 3600},
+		{ID: "replacement", Status: "active", ExpireTime: now + 90*24*3600},
+	}
+	var searched []string
+	planner := &sdk.RotationPlanner{
+		AccessKeys: &mocksmgmt.MockAccessKey{
+			SearchAllAssert:   func(tenantIDs []string) { searched = tenantIDs },
+			SearchAllResponse: keys,
+		},
+		Window:    7 * 24 * time.Hour,
+		TenantIDs: []string{"t1"},
+		Pending:   []*descope.AccessKeyRotation{{OldKey: &descope.AccessKeyResponse{ID: "rotated"}, DeactivateAt: time.Now().Add(time.Hour)}},
+	}
+	due, err := planner.Plan()
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, "expired", due[0].ID)
+	assert.Equal(t, "soon", due[1].ID)
+	assert.EqualValues(t, []string{"t1"}, searched)
+
+	planner.AccessKeys = &mocksmgmt.MockAccessKey{SearchAllError: descope.ErrRateLimitExceeded}
+	_, err = planner.Plan()
+	assert.ErrorIs(t, err, descope.ErrRateLimitExceeded)
+}
+
+func TestRotationPlannerRotate(t *testing.T) {
+	rotated := []string{}
+	mock := &mocksmgmt.MockAccessKey{
+		RotateAssert: func(id string, gracePeriod time.Duration) {
+			rotated = append(rotated, id)
+			assert.Equal(t, time.Hour, gracePeriod)
+		},
+		RotateResponse: &descope.AccessKeyRotation{Cleartext: "secret"},
+	}
+	planner := &sdk.RotationPlanner{AccessKeys: mock, GracePeriod: time.Hour}
+	rotations, err := planner.Rotate([]*descope.AccessKeyResponse{{ID: "k1"}, {ID: "k2"}})
+	require.NoError(t, err)
+	require.Len(t, rotations, 2)
+	assert.Equal(t, "secret", rotations[0].Cleartext)
+	assert.EqualValues(t, []string{"k1", "k2"}, rotated)
+	assert.Len(t, planner.Pending, 2)
+
+	mock.RotateError = descope.ErrRateLimitExceeded
+	rotations, err = planner.Rotate([]*descope.AccessKeyResponse{{ID: "k3"}})
+	assert.ErrorIs(t, err, descope.ErrRateLimitExceeded)
+	assert.Empty(t, rotations)
+
+	// without a grace period the old keys are deactivated immediately
+	planner = &sdk.RotationPlanner{AccessKeys: &mocksmgmt.MockAccessKey{RotateResponse: &descope.AccessKeyRotation{}}}
+	_, err = planner.Rotate([]*descope.AccessKeyResponse{{ID: "k1"}})
+	require.NoError(t, err)
+	assert.Empty(t, planner.Pending)
+}
+
+func TestRotationPlannerDeactivateRotated(t *testing.T) {
+	rotation := func(id string, deactivateAt time.Time) *descope.AccessKeyRotation {
+		return &descope.AccessKeyRotation{OldKey: &descope.AccessKeyResponse{ID: id}, DeactivateAt: deactivateAt}
+	}
+	deactivated := []string{}
+	mock := &mocksmgmt.MockAccessKey{
+		DeactivateAssert: func(id string) { deactivated = append(deactivated, id) },
+	}
+	planner := &sdk.RotationPlanner{AccessKeys: mock, Pending: []*descope.AccessKeyRotation{
+		rotation("old1", time.Now().Add(-time.Minute)),
+		rotation("old2", time.Now().Add(time.Hour)),
+		rotation("old3", time.Now().Add(-time.Minute)),
+	}}
+	ids, err := planner.DeactivateRotated()
+	require.NoError(t, err)
+	assert.EqualValues(t, []string{"old1", "old3"}, ids)
+	assert.EqualValues(t, []string{"old1", "old3"}, deactivated)
+	require.Len(t, planner.Pending, 1)
+	assert.Equal(t, "old2", planner.Pending[0].OldKey.ID)
+
+	// failed deactivations stay pending
+	planner.Pending = append(planner.Pending, rotation("old4", time.Now().Add(-time.Minute)))
+	mock.DeactivateError = descope.ErrRateLimitExceeded
+	ids, err = planner.DeactivateRotated()
+	assert.ErrorIs(t, err, descope.ErrRateLimitExceeded)
+	assert.Empty(t, ids)
+	assert.Len(t, planner.Pending, 2)
+}
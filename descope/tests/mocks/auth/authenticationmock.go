@@ -12,8 +12,11 @@ type MockAuthentication struct {
 	*MockEnchantedLink
 	*MockOTP
 	*MockTOTP
+	*MockPassword
+	*MockFlow
 	*MockOAuth
 	*MockSAML
+	*MockSSO
 	*MockWebAuthn
 	MockSession
 }
@@ -34,6 +37,14 @@ func (m *MockAuthentication) TOTP() sdk.TOTP {
 	return m.MockTOTP
 }
 
+func (m *MockAuthentication) Password() sdk.Password {
+	return m.MockPassword
+}
+
+func (m *MockAuthentication) Flow() sdk.Flow {
+	return m.MockFlow
+}
+
 func (m *MockAuthentication) OAuth() sdk.OAuth {
 	return m.MockOAuth
 }
@@ -42,6 +53,10 @@ func (m *MockAuthentication) SAML() sdk.SAML {
 	return m.MockSAML
 }
 
+func (m *MockAuthentication) SSO() sdk.SSOServiceProvider {
+	return m.MockSSO
+}
+
 func (m *MockAuthentication) WebAuthn() sdk.WebAuthn {
 	return m.MockWebAuthn
 }
@@ -285,6 +300,96 @@ func (m *MockTOTP) UpdateUser(loginID string, r *http.Request) (*descope.TOTPRes
 	return m.UpdateUserResponse, m.UpdateUserError
 }
 
+// Mock Password
+
+type MockPassword struct {
+	SignUpAssert   func(loginID string, user *descope.User, password string, w http.ResponseWriter)
+	SignUpError    error
+	SignUpResponse *descope.AuthenticationInfo
+
+	SignInAssert   func(loginID, password string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter)
+	SignInError    error
+	SignInResponse *descope.AuthenticationInfo
+
+	SendPasswordResetAssert func(loginID, redirectURL string)
+	SendPasswordResetError  error
+
+	UpdatePasswordAssert func(loginID, newPassword string, r *http.Request)
+	UpdatePasswordError  error
+
+	ReplacePasswordAssert   func(loginID, oldPassword, newPassword string, w http.ResponseWriter)
+	ReplacePasswordError    error
+	ReplacePasswordResponse *descope.AuthenticationInfo
+
+	GetPolicyError    error
+	GetPolicyResponse *descope.PasswordPolicy
+}
+
+func (m *MockPassword) SignUp(loginID string, user *descope.User, password string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if m.SignUpAssert != nil {
+		m.SignUpAssert(loginID, user, password, w)
+	}
+	return m.SignUpResponse, m.SignUpError
+}
+
+func (m *MockPassword) SignIn(loginID, password string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if m.SignInAssert != nil {
+		m.SignInAssert(loginID, password, r, loginOptions, w)
+	}
+	return m.SignInResponse, m.SignInError
+}
+
+func (m *MockPassword) SendPasswordReset(loginID, redirectURL string) error {
+	if m.SendPasswordResetAssert != nil {
+		m.SendPasswordResetAssert(loginID, redirectURL)
+	}
+	return m.SendPasswordResetError
+}
+
+func (m *MockPassword) UpdatePassword(loginID, newPassword string, r *http.Request) error {
+	if m.UpdatePasswordAssert != nil {
+		m.UpdatePasswordAssert(loginID, newPassword, r)
+	}
+	return m.UpdatePasswordError
+}
+
+func (m *MockPassword) ReplacePassword(loginID, oldPassword, newPassword string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if m.ReplacePasswordAssert != nil {
+		m.ReplacePasswordAssert(loginID, oldPassword, newPassword, w)
+	}
+	return m.ReplacePasswordResponse, m.ReplacePasswordError
+}
+
+func (m *MockPassword) GetPolicy() (*descope.PasswordPolicy, error) {
+	return m.GetPolicyResponse, m.GetPolicyError
+}
+
+// Mock Flow
+
+type MockFlow struct {
+	StartAssert   func(flowID string, input map[string]any, w http.ResponseWriter)
+	StartError    error
+	StartResponse *descope.FlowResponse
+
+	NextAssert   func(executionID, stepID, interactionID string, input map[string]any, w http.ResponseWriter)
+	NextError    error
+	NextResponse *descope.FlowResponse
+}
+
+func (m *MockFlow) Start(flowID string, input map[string]any, w http.ResponseWriter) (*descope.FlowResponse, error) {
+	if m.StartAssert != nil {
+		m.StartAssert(flowID, input, w)
+	}
+	return m.StartResponse, m.StartError
+}
+
+func (m *MockFlow) Next(executionID, stepID, interactionID string, input map[string]any, w http.ResponseWriter) (*descope.FlowResponse, error) {
+	if m.NextAssert != nil {
+		m.NextAssert(executionID, stepID, interactionID, input, w)
+	}
+	return m.NextResponse, m.NextError
+}
+
 // Mock OAuth
 
 type MockOAuth struct {
@@ -292,9 +397,17 @@ type MockOAuth struct {
 	StartError    error
 	StartResponse string
 
+	StartWithOptionsAssert   func(provider descope.OAuthProvider, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, options *descope.OAuthOptions, w http.ResponseWriter)
+	StartWithOptionsError    error
+	StartWithOptionsResponse string
+
 	ExchangeTokenAssert   func(code string, w http.ResponseWriter)
 	ExchangeTokenError    error
 	ExchangeTokenResponse *descope.AuthenticationInfo
+
+	ExchangeTokenWithOptionsAssert   func(code string, r *http.Request, options *descope.OAuthExchangeOptions, w http.ResponseWriter)
+	ExchangeTokenWithOptionsError    error
+	ExchangeTokenWithOptionsResponse *descope.AuthenticationInfo
 }
 
 func (m *MockOAuth) Start(provider descope.OAuthProvider, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (string, error) {
@@ -304,6 +417,13 @@ func (m *MockOAuth) Start(provider descope.OAuthProvider, returnURL string, r *h
 	return m.StartResponse, m.StartError
 }
 
+func (m *MockOAuth) StartWithOptions(provider descope.OAuthProvider, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, options *descope.OAuthOptions, w http.ResponseWriter) (string, error) {
+	if m.StartWithOptionsAssert != nil {
+		m.StartWithOptionsAssert(provider, returnURL, r, loginOptions, options, w)
+	}
+	return m.StartWithOptionsResponse, m.StartWithOptionsError
+}
+
 func (m *MockOAuth) ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
 	if m.ExchangeTokenAssert != nil {
 		m.ExchangeTokenAssert(code, w)
@@ -311,6 +431,13 @@ func (m *MockOAuth) ExchangeToken(code string, w http.ResponseWriter) (*descope.
 	return m.ExchangeTokenResponse, m.ExchangeTokenError
 }
 
+func (m *MockOAuth) ExchangeTokenWithOptions(code string, r *http.Request, options *descope.OAuthExchangeOptions, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if m.ExchangeTokenWithOptionsAssert != nil {
+		m.ExchangeTokenWithOptionsAssert(code, r, options, w)
+	}
+	return m.ExchangeTokenWithOptionsResponse, m.ExchangeTokenWithOptionsError
+}
+
 // Mock SAML
 
 type MockSAML struct {
@@ -337,6 +464,43 @@ func (m *MockSAML) ExchangeToken(code string, w http.ResponseWriter) (*descope.A
 	return m.ExchangeTokenResponse, m.ExchangeTokenError
 }
 
+// Mock SSO
+
+type MockSSO struct {
+	StartAssert   func(tenant string, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter)
+	StartError    error
+	StartResponse string
+
+	StartByEmailAssert   func(email string, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter)
+	StartByEmailError    error
+	StartByEmailResponse string
+
+	ExchangeTokenAssert   func(code string, w http.ResponseWriter)
+	ExchangeTokenError    error
+	ExchangeTokenResponse *descope.AuthenticationInfo
+}
+
+func (m *MockSSO) Start(tenant string, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (redirectURL string, err error) {
+	if m.StartAssert != nil {
+		m.StartAssert(tenant, returnURL, r, loginOptions, w)
+	}
+	return m.StartResponse, m.StartError
+}
+
+func (m *MockSSO) StartByEmail(email string, returnURL string, r *http.Request, loginOptions *descope.LoginOptions, w http.ResponseWriter) (redirectURL string, err error) {
+	if m.StartByEmailAssert != nil {
+		m.StartByEmailAssert(email, returnURL, r, loginOptions, w)
+	}
+	return m.StartByEmailResponse, m.StartByEmailError
+}
+
+func (m *MockSSO) ExchangeToken(code string, w http.ResponseWriter) (*descope.AuthenticationInfo, error) {
+	if m.ExchangeTokenAssert != nil {
+		m.ExchangeTokenAssert(code, w)
+	}
+	return m.ExchangeTokenResponse, m.ExchangeTokenError
+}
+
 // Mock WebAuthn
 
 type MockWebAuthn struct {
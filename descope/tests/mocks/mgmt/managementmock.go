@@ -1,6 +1,9 @@
 package mocksmgmt
 
 import (
+	"io"
+	"time"
+
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/sdk"
 )
@@ -14,6 +17,9 @@ type MockManagement struct {
 	*MockPermission
 	*MockRole
 	*MockGroup
+	*MockAudit
+	*MockProject
+	*MockFlow
 }
 
 func (m *MockManagement) JWT() sdk.JWT {
@@ -48,6 +54,18 @@ func (m *MockManagement) Group() sdk.Group {
 	return m.MockGroup
 }
 
+func (m *MockManagement) Audit() sdk.Audit {
+	return m.MockAudit
+}
+
+func (m *MockManagement) Project() sdk.Project {
+	return m.MockProject
+}
+
+func (m *MockManagement) Flow() sdk.FlowManagement {
+	return m.MockFlow
+}
+
 // Mock JWT
 
 type MockJWT struct {
@@ -74,6 +92,16 @@ type MockSSO struct {
 
 	ConfigureMappingAssert func(tenantID string, roleMappings []*descope.RoleMapping, attributeMapping *descope.AttributeMapping)
 	ConfigureMappingError  error
+
+	ConfigureOIDCSettingsAssert func(tenantID string, settings *descope.OIDCSettings)
+	ConfigureOIDCSettingsError  error
+
+	LoadSettingsAssert   func(tenantID string)
+	LoadSettingsResponse *descope.SSOSettingsResponse
+	LoadSettingsError    error
+
+	DeleteSettingsAssert func(tenantID string)
+	DeleteSettingsError  error
 }
 
 func (m *MockSSO) ConfigureSettings(tenantID, idpURL, idpCert, entityID, redirectURL string) error {
@@ -97,6 +125,27 @@ func (m *MockSSO) ConfigureMapping(tenantID string, roleMappings []*descope.Role
 	return m.ConfigureMappingError
 }
 
+func (m *MockSSO) ConfigureOIDCSettings(tenantID string, settings *descope.OIDCSettings) error {
+	if m.ConfigureOIDCSettingsAssert != nil {
+		m.ConfigureOIDCSettingsAssert(tenantID, settings)
+	}
+	return m.ConfigureOIDCSettingsError
+}
+
+func (m *MockSSO) LoadSettings(tenantID string) (*descope.SSOSettingsResponse, error) {
+	if m.LoadSettingsAssert != nil {
+		m.LoadSettingsAssert(tenantID)
+	}
+	return m.LoadSettingsResponse, m.LoadSettingsError
+}
+
+func (m *MockSSO) DeleteSettings(tenantID string) error {
+	if m.DeleteSettingsAssert != nil {
+		m.DeleteSettingsAssert(tenantID)
+	}
+	return m.DeleteSettingsError
+}
+
 // Mock User
 
 type MockUser struct {
@@ -104,10 +153,50 @@ type MockUser struct {
 	CreateResponse *descope.UserResponse
 	CreateError    error
 
+	CreateWithCustomAttributesAssert   func(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any)
+	CreateWithCustomAttributesResponse *descope.UserResponse
+	CreateWithCustomAttributesError    error
+
 	UpdateAssert   func(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant)
 	UpdateResponse *descope.UserResponse
 	UpdateError    error
 
+	UpdateWithCustomAttributesAssert   func(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any)
+	UpdateWithCustomAttributesResponse *descope.UserResponse
+	UpdateWithCustomAttributesError    error
+
+	CreateWithOptionsAssert   func(loginID string, user *descope.UserRequest)
+	CreateWithOptionsResponse *descope.UserResponse
+	CreateWithOptionsError    error
+
+	PatchAssert   func(loginID string, user *descope.UserRequest)
+	PatchResponse *descope.UserResponse
+	PatchError    error
+
+	CreateTestUserAssert   func(loginID string, user *descope.UserRequest)
+	CreateTestUserResponse *descope.UserResponse
+	CreateTestUserError    error
+
+	GenerateOTPForTestUserAssert   func(method descope.DeliveryMethod, loginID string)
+	GenerateOTPForTestUserResponse string
+	GenerateOTPForTestUserError    error
+
+	GenerateMagicLinkForTestUserAssert   func(method descope.DeliveryMethod, loginID, URI string)
+	GenerateMagicLinkForTestUserResponse string
+	GenerateMagicLinkForTestUserError    error
+
+	GenerateEnchantedLinkForTestUserAssert             func(loginID, URI string)
+	GenerateEnchantedLinkForTestUserResponseLink       string
+	GenerateEnchantedLinkForTestUserResponsePendingRef string
+	GenerateEnchantedLinkForTestUserError              error
+
+	ImpersonateAssert   func(impersonatorID, loginID string, validateConsent bool)
+	ImpersonateResponse string
+	ImpersonateError    error
+
+	DeleteAllTestUsersAssert func()
+	DeleteAllTestUsersError  error
+
 	DeleteAssert func(loginID string)
 	DeleteError  error
 
@@ -119,6 +208,18 @@ type MockUser struct {
 	SearchAllResponse []*descope.UserResponse
 	SearchAllError    error
 
+	SearchAllWithOptionsAssert   func(options *descope.UserSearchOptions)
+	SearchAllWithOptionsResponse []*descope.UserResponse
+	SearchAllWithOptionsError    error
+
+	SearchIteratorAssert   func(options *descope.UserSearchOptions)
+	SearchIteratorResponse []*descope.UserResponse
+	SearchIteratorError    error
+
+	BatchAssert   func(items []*descope.UserBatchItem, options *descope.UserBatchOptions)
+	BatchResponse []*descope.UserBatchResult
+	BatchError    error
+
 	ActivateAssert   func(loginID string)
 	ActivateResponse *descope.UserResponse
 	ActivateError    error
@@ -139,6 +240,10 @@ type MockUser struct {
 	UpdateDisplayNameResponse *descope.UserResponse
 	UpdateDisplayNameError    error
 
+	UpdateCustomAttributeAssert   func(loginID, key string, value any)
+	UpdateCustomAttributeResponse *descope.UserResponse
+	UpdateCustomAttributeError    error
+
 	AddRoleAssert   func(loginID string, roles []string)
 	AddRoleResponse *descope.UserResponse
 	AddRoleError    error
@@ -162,6 +267,12 @@ type MockUser struct {
 	RemoveTenantRoleAssert   func(loginID, tenantID string, roles []string)
 	RemoveTenantRoleResponse *descope.UserResponse
 	RemoveTenantRoleError    error
+
+	SetPasswordAssert func(loginID, password string)
+	SetPasswordError  error
+
+	ExpirePasswordAssert func(loginID string)
+	ExpirePasswordError  error
 }
 
 func (m *MockUser) Create(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant) (*descope.UserResponse, error) {
@@ -171,6 +282,13 @@ func (m *MockUser) Create(loginID, email, phone, displayName string, roles []str
 	return m.CreateResponse, m.CreateError
 }
 
+func (m *MockUser) CreateWithCustomAttributes(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any) (*descope.UserResponse, error) {
+	if m.CreateWithCustomAttributesAssert != nil {
+		m.CreateWithCustomAttributesAssert(loginID, email, phone, displayName, roles, tenants, customAttributes)
+	}
+	return m.CreateWithCustomAttributesResponse, m.CreateWithCustomAttributesError
+}
+
 func (m *MockUser) Update(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant) (*descope.UserResponse, error) {
 	if m.UpdateAssert != nil {
 		m.UpdateAssert(loginID, email, phone, displayName, roles, tenants)
@@ -178,6 +296,69 @@ func (m *MockUser) Update(loginID, email, phone, displayName string, roles []str
 	return m.UpdateResponse, m.UpdateError
 }
 
+func (m *MockUser) UpdateWithCustomAttributes(loginID, email, phone, displayName string, roles []string, tenants []*descope.AssociatedTenant, customAttributes map[string]any) (*descope.UserResponse, error) {
+	if m.UpdateWithCustomAttributesAssert != nil {
+		m.UpdateWithCustomAttributesAssert(loginID, email, phone, displayName, roles, tenants, customAttributes)
+	}
+	return m.UpdateWithCustomAttributesResponse, m.UpdateWithCustomAttributesError
+}
+
+func (m *MockUser) CreateWithOptions(loginID string, user *descope.UserRequest) (*descope.UserResponse, error) {
+	if m.CreateWithOptionsAssert != nil {
+		m.CreateWithOptionsAssert(loginID, user)
+	}
+	return m.CreateWithOptionsResponse, m.CreateWithOptionsError
+}
+
+func (m *MockUser) Patch(loginID string, user *descope.UserRequest) (*descope.UserResponse, error) {
+	if m.PatchAssert != nil {
+		m.PatchAssert(loginID, user)
+	}
+	return m.PatchResponse, m.PatchError
+}
+
+func (m *MockUser) CreateTestUser(loginID string, user *descope.UserRequest) (*descope.UserResponse, error) {
+	if m.CreateTestUserAssert != nil {
+		m.CreateTestUserAssert(loginID, user)
+	}
+	return m.CreateTestUserResponse, m.CreateTestUserError
+}
+
+func (m *MockUser) GenerateOTPForTestUser(method descope.DeliveryMethod, loginID string) (string, error) {
+	if m.GenerateOTPForTestUserAssert != nil {
+		m.GenerateOTPForTestUserAssert(method, loginID)
+	}
+	return m.GenerateOTPForTestUserResponse, m.GenerateOTPForTestUserError
+}
+
+func (m *MockUser) GenerateMagicLinkForTestUser(method descope.DeliveryMethod, loginID, URI string) (string, error) {
+	if m.GenerateMagicLinkForTestUserAssert != nil {
+		m.GenerateMagicLinkForTestUserAssert(method, loginID, URI)
+	}
+	return m.GenerateMagicLinkForTestUserResponse, m.GenerateMagicLinkForTestUserError
+}
+
+func (m *MockUser) GenerateEnchantedLinkForTestUser(loginID, URI string) (string, string, error) {
+	if m.GenerateEnchantedLinkForTestUserAssert != nil {
+		m.GenerateEnchantedLinkForTestUserAssert(loginID, URI)
+	}
+	return m.GenerateEnchantedLinkForTestUserResponseLink, m.GenerateEnchantedLinkForTestUserResponsePendingRef, m.GenerateEnchantedLinkForTestUserError
+}
+
+func (m *MockUser) Impersonate(impersonatorID, loginID string, validateConsent bool) (string, error) {
+	if m.ImpersonateAssert != nil {
+		m.ImpersonateAssert(impersonatorID, loginID, validateConsent)
+	}
+	return m.ImpersonateResponse, m.ImpersonateError
+}
+
+func (m *MockUser) DeleteAllTestUsers() error {
+	if m.DeleteAllTestUsersAssert != nil {
+		m.DeleteAllTestUsersAssert()
+	}
+	return m.DeleteAllTestUsersError
+}
+
 func (m *MockUser) Delete(loginID string) error {
 	if m.DeleteAssert != nil {
 		m.DeleteAssert(loginID)
@@ -206,6 +387,59 @@ func (m *MockUser) SearchAll(tenantIDs, roles []string, limit int32) ([]*descope
 	return m.SearchAllResponse, m.SearchAllError
 }
 
+func (m *MockUser) SearchAllWithOptions(options *descope.UserSearchOptions) ([]*descope.UserResponse, error) {
+	if m.SearchAllWithOptionsAssert != nil {
+		m.SearchAllWithOptionsAssert(options)
+	}
+	return m.SearchAllWithOptionsResponse, m.SearchAllWithOptionsError
+}
+
+func (m *MockUser) SearchIterator(options *descope.UserSearchOptions) sdk.UserIterator {
+	if m.SearchIteratorAssert != nil {
+		m.SearchIteratorAssert(options)
+	}
+	return &MockUserIterator{Users: m.SearchIteratorResponse, Error: m.SearchIteratorError}
+}
+
+func (m *MockUser) Batch(items []*descope.UserBatchItem, options *descope.UserBatchOptions) ([]*descope.UserBatchResult, error) {
+	if m.BatchAssert != nil {
+		m.BatchAssert(items, options)
+	}
+	return m.BatchResponse, m.BatchError
+}
+
+// Iterates over the given users, and then fails with the given error if it's set
+type MockUserIterator struct {
+	Users []*descope.UserResponse
+	Error error
+
+	index   int
+	current *descope.UserResponse
+	done    bool
+}
+
+func (m *MockUserIterator) Next() bool {
+	if m.index >= len(m.Users) {
+		m.current = nil
+		m.done = true
+		return false
+	}
+	m.current = m.Users[m.index]
+	m.index++
+	return true
+}
+
+func (m *MockUserIterator) User() *descope.UserResponse {
+	return m.current
+}
+
+func (m *MockUserIterator) Err() error {
+	if !m.done {
+		return nil
+	}
+	return m.Error
+}
+
 func (m *MockUser) Activate(loginID string) (*descope.UserResponse, error) {
 	if m.ActivateAssert != nil {
 		m.ActivateAssert(loginID)
@@ -241,6 +475,13 @@ func (m *MockUser) UpdateDisplayName(loginID, displayName string) (*descope.User
 	return m.UpdateDisplayNameResponse, m.UpdateDisplayNameError
 }
 
+func (m *MockUser) UpdateCustomAttribute(loginID, key string, value any) (*descope.UserResponse, error) {
+	if m.UpdateCustomAttributeAssert != nil {
+		m.UpdateCustomAttributeAssert(loginID, key, value)
+	}
+	return m.UpdateCustomAttributeResponse, m.UpdateCustomAttributeError
+}
+
 func (m *MockUser) AddRoles(loginID string, roles []string) (*descope.UserResponse, error) {
 	if m.AddRoleAssert != nil {
 		m.AddRoleAssert(loginID, roles)
@@ -283,6 +524,20 @@ func (m *MockUser) RemoveTenantRoles(loginID string, tenantID string, roles []st
 	return m.RemoveTenantRoleResponse, m.RemoveTenantRoleError
 }
 
+func (m *MockUser) SetPassword(loginID string, password string) error {
+	if m.SetPasswordAssert != nil {
+		m.SetPasswordAssert(loginID, password)
+	}
+	return m.SetPasswordError
+}
+
+func (m *MockUser) ExpirePassword(loginID string) error {
+	if m.ExpirePasswordAssert != nil {
+		m.ExpirePasswordAssert(loginID)
+	}
+	return m.ExpirePasswordError
+}
+
 // Mock Access Key
 
 type MockAccessKey struct {
@@ -290,6 +545,10 @@ type MockAccessKey struct {
 	CreateResponseFn func() (string, *descope.AccessKeyResponse)
 	CreateError      error
 
+	CreateWithOptionsAssert     func(key *descope.AccessKeyRequest)
+	CreateWithOptionsResponseFn func() (string, *descope.AccessKeyResponse)
+	CreateWithOptionsError      error
+
 	LoadAssert   func(id string)
 	LoadResponse *descope.AccessKeyResponse
 	LoadError    error
@@ -302,6 +561,10 @@ type MockAccessKey struct {
 	UpdateResponse *descope.AccessKeyResponse
 	UpdateError    error
 
+	UpdateWithOptionsAssert   func(id string, key *descope.AccessKeyRequest)
+	UpdateWithOptionsResponse *descope.AccessKeyResponse
+	UpdateWithOptionsError    error
+
 	DeactivateAssert func(id string)
 	DeactivateError  error
 
@@ -310,6 +573,10 @@ type MockAccessKey struct {
 
 	DeleteAssert func(id string)
 	DeleteError  error
+
+	RotateAssert   func(id string, gracePeriod time.Duration)
+	RotateResponse *descope.AccessKeyRotation
+	RotateError    error
 }
 
 func (m *MockAccessKey) Create(name string, expireTime int64, roles []string, keyTenants []*descope.AssociatedTenant) (string, *descope.AccessKeyResponse, error) {
@@ -324,6 +591,18 @@ func (m *MockAccessKey) Create(name string, expireTime int64, roles []string, ke
 	return cleartext, key, m.CreateError
 }
 
+func (m *MockAccessKey) CreateWithOptions(key *descope.AccessKeyRequest) (string, *descope.AccessKeyResponse, error) {
+	if m.CreateWithOptionsAssert != nil {
+		m.CreateWithOptionsAssert(key)
+	}
+	var cleartext string
+	var res *descope.AccessKeyResponse
+	if m.CreateWithOptionsResponseFn != nil {
+		cleartext, res = m.CreateWithOptionsResponseFn()
+	}
+	return cleartext, res, m.CreateWithOptionsError
+}
+
 func (m *MockAccessKey) Load(id string) (*descope.AccessKeyResponse, error) {
 	if m.LoadAssert != nil {
 		m.LoadAssert(id)
@@ -345,6 +624,13 @@ func (m *MockAccessKey) Update(id, name string) (*descope.AccessKeyResponse, err
 	return m.UpdateResponse, m.UpdateError
 }
 
+func (m *MockAccessKey) UpdateWithOptions(id string, key *descope.AccessKeyRequest) (*descope.AccessKeyResponse, error) {
+	if m.UpdateWithOptionsAssert != nil {
+		m.UpdateWithOptionsAssert(id, key)
+	}
+	return m.UpdateWithOptionsResponse, m.UpdateWithOptionsError
+}
+
 func (m *MockAccessKey) Deactivate(id string) error {
 	if m.DeactivateAssert != nil {
 		m.DeactivateAssert(id)
@@ -366,6 +652,13 @@ func (m *MockAccessKey) Delete(id string) error {
 	return m.DeleteError
 }
 
+func (m *MockAccessKey) Rotate(id string, gracePeriod time.Duration) (*descope.AccessKeyRotation, error) {
+	if m.RotateAssert != nil {
+		m.RotateAssert(id, gracePeriod)
+	}
+	return m.RotateResponse, m.RotateError
+}
+
 // Mock Tenant
 
 type MockTenant struct {
@@ -376,14 +669,36 @@ type MockTenant struct {
 	CreateWithIDAssert func(id, name string, selfProvisioningDomains []string)
 	CreateWithIDError  error
 
+	CreateWithOptionsAssert   func(id string, tenant *descope.TenantRequest)
+	CreateWithOptionsResponse string
+	CreateWithOptionsError    error
+
 	UpdateAssert func(id, name string, selfProvisioningDomains []string)
 	UpdateError  error
 
+	UpdateWithOptionsAssert func(id string, tenant *descope.TenantRequest)
+	UpdateWithOptionsError  error
+
 	DeleteAssert func(id string)
 	DeleteError  error
 
+	LoadAssert   func(id string)
+	LoadResponse *descope.Tenant
+	LoadError    error
+
 	LoadAllResponse []*descope.Tenant
 	LoadAllError    error
+
+	SearchAllAssert   func(options *descope.TenantSearchOptions)
+	SearchAllResponse []*descope.Tenant
+	SearchAllError    error
+
+	GetSettingsAssert   func(id string)
+	GetSettingsResponse *descope.TenantSettings
+	GetSettingsError    error
+
+	ConfigureSettingsAssert func(id string, settings *descope.TenantSettings)
+	ConfigureSettingsError  error
 }
 
 func (m *MockTenant) Create(name string, selfProvisioningDomains []string) (id string, err error) {
@@ -400,6 +715,13 @@ func (m *MockTenant) CreateWithID(id, name string, selfProvisioningDomains []str
 	return m.CreateWithIDError
 }
 
+func (m *MockTenant) CreateWithOptions(id string, tenant *descope.TenantRequest) (string, error) {
+	if m.CreateWithOptionsAssert != nil {
+		m.CreateWithOptionsAssert(id, tenant)
+	}
+	return m.CreateWithOptionsResponse, m.CreateWithOptionsError
+}
+
 func (m *MockTenant) Update(id, name string, selfProvisioningDomains []string) error {
 	if m.UpdateAssert != nil {
 		m.UpdateAssert(id, name, selfProvisioningDomains)
@@ -407,6 +729,13 @@ func (m *MockTenant) Update(id, name string, selfProvisioningDomains []string) e
 	return m.UpdateError
 }
 
+func (m *MockTenant) UpdateWithOptions(id string, tenant *descope.TenantRequest) error {
+	if m.UpdateWithOptionsAssert != nil {
+		m.UpdateWithOptionsAssert(id, tenant)
+	}
+	return m.UpdateWithOptionsError
+}
+
 func (m *MockTenant) Delete(id string) error {
 	if m.DeleteAssert != nil {
 		m.DeleteAssert(id)
@@ -414,10 +743,38 @@ func (m *MockTenant) Delete(id string) error {
 	return m.DeleteError
 }
 
+func (m *MockTenant) Load(id string) (*descope.Tenant, error) {
+	if m.LoadAssert != nil {
+		m.LoadAssert(id)
+	}
+	return m.LoadResponse, m.LoadError
+}
+
 func (m *MockTenant) LoadAll() ([]*descope.Tenant, error) {
 	return m.LoadAllResponse, m.LoadAllError
 }
 
+func (m *MockTenant) SearchAll(options *descope.TenantSearchOptions) ([]*descope.Tenant, error) {
+	if m.SearchAllAssert != nil {
+		m.SearchAllAssert(options)
+	}
+	return m.SearchAllResponse, m.SearchAllError
+}
+
+func (m *MockTenant) GetSettings(id string) (*descope.TenantSettings, error) {
+	if m.GetSettingsAssert != nil {
+		m.GetSettingsAssert(id)
+	}
+	return m.GetSettingsResponse, m.GetSettingsError
+}
+
+func (m *MockTenant) ConfigureSettings(id string, settings *descope.TenantSettings) error {
+	if m.ConfigureSettingsAssert != nil {
+		m.ConfigureSettingsAssert(id, settings)
+	}
+	return m.ConfigureSettingsError
+}
+
 // Mock Permission
 
 type MockPermission struct {
@@ -514,6 +871,25 @@ type MockGroup struct {
 	LoadAllGroupMembersAssert   func(tenantID, groupID string)
 	LoadAllGroupMembersResponse []*descope.Group
 	LoadAllGroupMembersError    error
+
+	CreateAssert func(tenantID, groupID, display string, userIDs []string)
+	CreateError  error
+
+	UpdateAssert func(tenantID, groupID, display string)
+	UpdateError  error
+
+	DeleteAssert func(tenantID, groupID string)
+	DeleteError  error
+
+	AddMembersAssert func(tenantID, groupID string, userIDs, loginIDs []string)
+	AddMembersError  error
+
+	RemoveMembersAssert func(tenantID, groupID string, userIDs, loginIDs []string)
+	RemoveMembersError  error
+
+	SyncGroupsAssert   func(tenantID string, desired []descope.Group)
+	SyncGroupsResponse *descope.GroupSyncResult
+	SyncGroupsError    error
 }
 
 func (m *MockGroup) LoadAllGroups(tenantID string) ([]*descope.Group, error) {
@@ -536,3 +912,174 @@ func (m *MockGroup) LoadAllGroupMembers(tenantID, groupID string) ([]*descope.Gr
 	}
 	return m.LoadAllGroupMembersResponse, m.LoadAllGroupMembersError
 }
+
+func (m *MockGroup) Create(tenantID, groupID, display string, userIDs []string) error {
+	if m.CreateAssert != nil {
+		m.CreateAssert(tenantID, groupID, display, userIDs)
+	}
+	return m.CreateError
+}
+
+func (m *MockGroup) Update(tenantID, groupID, display string) error {
+	if m.UpdateAssert != nil {
+		m.UpdateAssert(tenantID, groupID, display)
+	}
+	return m.UpdateError
+}
+
+func (m *MockGroup) Delete(tenantID, groupID string) error {
+	if m.DeleteAssert != nil {
+		m.DeleteAssert(tenantID, groupID)
+	}
+	return m.DeleteError
+}
+
+func (m *MockGroup) AddMembers(tenantID, groupID string, userIDs, loginIDs []string) error {
+	if m.AddMembersAssert != nil {
+		m.AddMembersAssert(tenantID, groupID, userIDs, loginIDs)
+	}
+	return m.AddMembersError
+}
+
+func (m *MockGroup) RemoveMembers(tenantID, groupID string, userIDs, loginIDs []string) error {
+	if m.RemoveMembersAssert != nil {
+		m.RemoveMembersAssert(tenantID, groupID, userIDs, loginIDs)
+	}
+	return m.RemoveMembersError
+}
+
+func (m *MockGroup) SyncGroups(tenantID string, desired []descope.Group) (*descope.GroupSyncResult, error) {
+	if m.SyncGroupsAssert != nil {
+		m.SyncGroupsAssert(tenantID, desired)
+	}
+	return m.SyncGroupsResponse, m.SyncGroupsError
+}
+
+// Mock Audit
+
+type MockAudit struct {
+	SearchAssert   func(options *descope.AuditSearchOptions)
+	SearchResponse []*descope.AuditRecord
+	SearchError    error
+
+	ExportAssert   func(w io.Writer, options *descope.AuditSearchOptions)
+	ExportResponse int
+	ExportError    error
+}
+
+func (m *MockAudit) Search(options *descope.AuditSearchOptions) sdk.AuditIterator {
+	if m.SearchAssert != nil {
+		m.SearchAssert(options)
+	}
+	return &MockAuditIterator{Records: m.SearchResponse, Error: m.SearchError}
+}
+
+func (m *MockAudit) Export(w io.Writer, options *descope.AuditSearchOptions) (int, error) {
+	if m.ExportAssert != nil {
+		m.ExportAssert(w, options)
+	}
+	return m.ExportResponse, m.ExportError
+}
+
+// Iterates over the given records, and then fails with the given error if it's set
+type MockAuditIterator struct {
+	Records []*descope.AuditRecord
+	Error   error
+
+	index   int
+	current *descope.AuditRecord
+	done    bool
+}
+
+func (m *MockAuditIterator) Next() bool {
+	if m.index >= len(m.Records) {
+		m.current = nil
+		m.done = true
+		return false
+	}
+	m.current = m.Records[m.index]
+	m.index++
+	return true
+}
+
+func (m *MockAuditIterator) Record() *descope.AuditRecord {
+	return m.current
+}
+
+func (m *MockAuditIterator) Err() error {
+	if !m.done {
+		return nil
+	}
+	return m.Error
+}
+
+// Mock Project
+
+type MockProject struct {
+	ExportResponse *descope.ProjectSnapshot
+	ExportError    error
+
+	ImportAssert   func(snapshot *descope.ProjectSnapshot, options *descope.ProjectImportOptions)
+	ImportResponse []*descope.ProjectImportChange
+	ImportError    error
+}
+
+func (m *MockProject) Export() (*descope.ProjectSnapshot, error) {
+	return m.ExportResponse, m.ExportError
+}
+
+func (m *MockProject) Import(snapshot *descope.ProjectSnapshot, options *descope.ProjectImportOptions) ([]*descope.ProjectImportChange, error) {
+	if m.ImportAssert != nil {
+		m.ImportAssert(snapshot, options)
+	}
+	return m.ImportResponse, m.ImportError
+}
+
+// Mock Flow
+
+type MockFlow struct {
+	ListFlowsResponse []*descope.FlowMetadata
+	ListFlowsError    error
+
+	ExportFlowAssert   func(flowID string)
+	ExportFlowResponse map[string]any
+	ExportFlowError    error
+
+	ImportFlowAssert func(flowID string, flowJSON map[string]any)
+	ImportFlowError  error
+
+	ExportThemeResponse map[string]any
+	ExportThemeError    error
+
+	ImportThemeAssert func(theme map[string]any)
+	ImportThemeError  error
+}
+
+func (m *MockFlow) ListFlows() ([]*descope.FlowMetadata, error) {
+	return m.ListFlowsResponse, m.ListFlowsError
+}
+
+func (m *MockFlow) ExportFlow(flowID string) (map[string]any, error) {
+	if m.ExportFlowAssert != nil {
+		m.ExportFlowAssert(flowID)
+	}
+	return m.ExportFlowResponse, m.ExportFlowError
+}
+
+func (m *MockFlow) ImportFlow(flowID string, flowJSON map[string]any) error {
+	if m.ImportFlowAssert != nil {
+		m.ImportFlowAssert(flowID, flowJSON)
+	}
+	return m.ImportFlowError
+}
+
+func (m *MockFlow) ExportTheme() (map[string]any, error) {
+	return m.ExportThemeResponse, m.ExportThemeError
+}
+
+func (m *MockFlow) ImportTheme(theme map[string]any) error {
+	if m.ImportThemeAssert != nil {
+		m.ImportThemeAssert(theme)
+	}
+	return m.ImportThemeError
+}
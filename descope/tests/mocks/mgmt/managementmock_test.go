@@ -35,3 +35,68 @@ func TestMockManagement(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, expectedLoginID, u.UserID)
 }
+
+func TestMockTestUsers(t *testing.T) {
+	descopeClient := client.DescopeClient{
+		Management: &MockManagement{
+			MockUser: &MockUser{
+				GenerateOTPForTestUserAssert: func(method descope.DeliveryMethod, loginID string) {
+					assert.Equal(t, descope.MethodEmail, method)
+					assert.Equal(t, "tester", loginID)
+				},
+				GenerateOTPForTestUserResponse:                     "123456",
+				GenerateEnchantedLinkForTestUserResponseLink:       "link",
+				GenerateEnchantedLinkForTestUserResponsePendingRef: "ref",
+				DeleteAllTestUsersError:                            descope.ErrBadRequest,
+			},
+		},
+	}
+	code, err := descopeClient.Management.User().GenerateOTPForTestUser(descope.MethodEmail, "tester")
+	require.NoError(t, err)
+	assert.Equal(t, "123456", code)
+	link, pendingRef, err := descopeClient.Management.User().GenerateEnchantedLinkForTestUser("tester", "")
+	require.NoError(t, err)
+	assert.Equal(t, "link", link)
+	assert.Equal(t, "ref", pendingRef)
+	assert.ErrorIs(t, descopeClient.Management.User().DeleteAllTestUsers(), descope.ErrBadRequest)
+}
+
+func TestMockUserIterator(t *testing.T) {
+	descopeClient := client.DescopeClient{
+		Management: &MockManagement{
+			MockUser: &MockUser{
+				SearchIteratorResponse: []*descope.UserResponse{{UserID: "u1"}, {UserID: "u2"}},
+				SearchIteratorError:    descope.ErrRateLimitExceeded,
+			},
+		},
+	}
+	it := descopeClient.Management.User().SearchIterator(nil)
+	ids := []string{}
+	for it.Next() {
+		assert.NoError(t, it.Err())
+		ids = append(ids, it.User().UserID)
+	}
+	assert.EqualValues(t, []string{"u1", "u2"}, ids)
+	assert.ErrorIs(t, it.Err(), descope.ErrRateLimitExceeded)
+	assert.Nil(t, it.User())
+}
+
+func TestMockAuditIterator(t *testing.T) {
+	descopeClient := client.DescopeClient{
+		Management: &MockManagement{
+			MockAudit: &MockAudit{
+				SearchResponse: []*descope.AuditRecord{{Action: "LoginSucceed"}, {Action: "LoginFailed"}},
+				SearchError:    descope.ErrRateLimitExceeded,
+			},
+		},
+	}
+	it := descopeClient.Management.Audit().Search(nil)
+	actions := []string{}
+	for it.Next() {
+		assert.NoError(t, it.Err())
+		actions = append(actions, it.Record().Action)
+	}
+	assert.EqualValues(t, []string{"LoginSucceed", "LoginFailed"}, actions)
+	assert.ErrorIs(t, it.Err(), descope.ErrRateLimitExceeded)
+	assert.Nil(t, it.Record())
+}
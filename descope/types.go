@@ -1,7 +1,9 @@
 package descope
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/descope/go-sdk/descope/logger"
 	"github.com/lestrrat-go/jwx/v2/jwt"
@@ -21,6 +23,8 @@ type AuthenticationInfo struct {
 	RefreshToken *Token        `json:"refreshToken,omitempty"`
 	User         *UserResponse `json:"user,omitempty"`
 	FirstSeen    bool          `json:"firstSeen,omitempty"`
+	// Only set after an OAuth authentication when the project allows it
+	ProviderTokens *ProviderTokens `json:"providerTokens,omitempty"`
 }
 
 type WebAuthnTransactionResponse struct {
@@ -34,6 +38,99 @@ type WebAuthnFinishRequest struct {
 	Response      string `json:"response,omitempty"`
 }
 
+// FlowAction - the kind of step a flow execution is waiting on, see FlowResponse
+type FlowAction string
+
+const (
+	// The flow is showing a screen, and continues when the user interacts with it
+	FlowActionScreen FlowAction = "screen"
+	// The flow continues after the user is redirected to a URL, e.g., for OAuth or SSO
+	FlowActionRedirect FlowAction = "redirect"
+	// The flow continues after a WebAuthn ceremony is performed on the user's device
+	FlowActionWebAuthn FlowAction = "webauthn"
+	// The flow is completed, and the user is authenticated
+	FlowActionCompleted FlowAction = "completed"
+)
+
+// FlowResponse - the current step of a flow execution. The field matching the Action
+// describes what the client should do before continuing the flow with the next step.
+type FlowResponse struct {
+	ExecutionID string        `json:"executionId,omitempty"`
+	StepID      string        `json:"stepId,omitempty"`
+	Action      FlowAction    `json:"action,omitempty"`
+	Screen      *FlowScreen   `json:"screen,omitempty"`
+	Redirect    *FlowRedirect `json:"redirect,omitempty"`
+	WebAuthn    *FlowWebAuthn `json:"webauthn,omitempty"`
+	// Set when the flow is completed
+	AuthInfo *AuthenticationInfo `json:"-"`
+}
+
+// FlowScreen - a screen that should be shown to the user
+type FlowScreen struct {
+	ID string `json:"id,omitempty"`
+	// The data needed to render the screen, e.g., error messages or values to display
+	State map[string]any `json:"state,omitempty"`
+	// The IDs of the interactions available on the screen, e.g., buttons
+	Interactions []string `json:"interactions,omitempty"`
+}
+
+// FlowRedirect - a URL the user should be redirected to
+type FlowRedirect struct {
+	URL string `json:"url,omitempty"`
+}
+
+// FlowWebAuthn - a WebAuthn ceremony that should be performed on the user's device
+type FlowWebAuthn struct {
+	TransactionID string `json:"transactionId,omitempty"`
+	Options       string `json:"options,omitempty"`
+	Create        bool   `json:"create,omitempty"`
+}
+
+// OAuthOptions - optional settings for starting an OAuth authentication
+type OAuthOptions struct {
+	// The PKCE code challenge, see NewPKCE. The matching code verifier must then be
+	// provided when exchanging the code.
+	CodeChallenge string `json:"codeChallenge,omitempty"`
+	// Overrides the scopes requested from the provider, instead of the ones configured in the console
+	Scopes []string `json:"scopes,omitempty"`
+	// The prompt sent to the provider, e.g., "consent" or "select_account"
+	Prompt string `json:"prompt,omitempty"`
+	// A hint for the provider about the account the user should sign in with, usually an email
+	LoginHint string `json:"loginHint,omitempty"`
+	// Additional parameters added to the provider's authorization URL
+	AuthParams map[string]string `json:"authParams,omitempty"`
+}
+
+// ProviderTokens - the tokens returned by the OAuth provider, only available when
+// the project is configured to share them in the Descope console
+type ProviderTokens struct {
+	Provider    string   `json:"provider,omitempty"`
+	AccessToken string   `json:"accessToken,omitempty"`
+	Expiration  int64    `json:"expiration,omitempty"` // Unix time in seconds
+	Scopes      []string `json:"scopes,omitempty"`
+}
+
+// OAuthExchangeOptions - optional settings for exchanging an OAuth code
+type OAuthExchangeOptions struct {
+	// The PKCE code verifier, required when a code challenge was provided when starting
+	CodeVerifier string `json:"codeVerifier,omitempty"`
+}
+
+// PasswordPolicy - the requirements that passwords must meet, as configured for the project
+type PasswordPolicy struct {
+	MinLength       int32 `json:"minLength,omitempty"`
+	Lowercase       bool  `json:"lowercase,omitempty"`
+	Uppercase       bool  `json:"uppercase,omitempty"`
+	Number          bool  `json:"number,omitempty"`
+	NonAlphanumeric bool  `json:"nonAlphanumeric,omitempty"`
+	Expiration      bool  `json:"expiration,omitempty"`
+	ExpirationWeeks int32 `json:"expirationWeeks,omitempty"`
+	Reuse           bool  `json:"reuse,omitempty"`
+	ReuseAmount     int32 `json:"reuseAmount,omitempty"`
+	Lock            bool  `json:"lock,omitempty"`
+	LockAttempts    int32 `json:"lockAttempts,omitempty"`
+}
+
 type AuthFactor string
 
 const AuthFactorUnknown AuthFactor = ""
@@ -43,6 +140,7 @@ const AuthFactorSaml AuthFactor = "fed"
 const AuthFactorOAuth AuthFactor = "oauth"
 const AuthFactorWebauthn AuthFactor = "webauthn"
 const AuthFactorTOTP AuthFactor = "totp"
+const AuthFactorPassword AuthFactor = "pwd"
 const AuthFactorMFA AuthFactor = "mfa"
 
 type Token struct {
@@ -83,6 +181,44 @@ func (to *Token) CustomClaim(value string) interface{} {
 	return nil
 }
 
+// The registered JWT claims and the claims Descope adds to every token, which
+// aren't returned by CustomClaims
+var standardClaims = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true, "iat": true, "jti": true,
+	"drn": true, "amr": true, "roles": true, "permissions": true, ClaimAuthorizedTenants: true, ClaimActor: true,
+}
+
+// CustomClaims returns the custom claims in the token with typed getters, for reading
+// custom user attributes that are added to the token as custom claims. The registered
+// JWT claims and the claims Descope adds to every token are left out.
+func (to *Token) CustomClaims() CustomAttributes {
+	claims := CustomAttributes{}
+	for k, v := range to.Claims {
+		if !standardClaims[k] {
+			claims[k] = v
+		}
+	}
+	return claims
+}
+
+// Impersonator returns the ID of the user who is impersonating the token's user,
+// or an empty string if the token isn't the result of an impersonation.
+func (to *Token) Impersonator() string {
+	if to.Claims != nil {
+		if act, ok := to.Claims[ClaimActor].(map[string]any); ok {
+			if sub, ok := act["sub"].(string); ok {
+				return sub
+			}
+		}
+	}
+	return ""
+}
+
+// IsImpersonated returns true if the token was created by impersonating its user.
+func (to *Token) IsImpersonated() bool {
+	return to.Impersonator() != ""
+}
+
 func (to *Token) AuthFactors() []AuthFactor {
 	if to.Claims == nil {
 		return nil
@@ -123,14 +259,15 @@ func (lo *LoginOptions) IsJWTRequired() bool {
 }
 
 type JWTResponse struct {
-	SessionJwt       string        `json:"sessionJwt,omitempty"`
-	RefreshJwt       string        `json:"refreshJwt,omitempty"`
-	CookieDomain     string        `json:"cookieDomain,omitempty"`
-	CookiePath       string        `json:"cookiePath,omitempty"`
-	CookieMaxAge     int32         `json:"cookieMaxAge,omitempty"`
-	CookieExpiration int32         `json:"cookieExpiration,omitempty"`
-	User             *UserResponse `json:"user,omitempty"`
-	FirstSeen        bool          `json:"firstSeen,omitempty"`
+	SessionJwt       string          `json:"sessionJwt,omitempty"`
+	RefreshJwt       string          `json:"refreshJwt,omitempty"`
+	CookieDomain     string          `json:"cookieDomain,omitempty"`
+	CookiePath       string          `json:"cookiePath,omitempty"`
+	CookieMaxAge     int32           `json:"cookieMaxAge,omitempty"`
+	CookieExpiration int32           `json:"cookieExpiration,omitempty"`
+	User             *UserResponse   `json:"user,omitempty"`
+	FirstSeen        bool            `json:"firstSeen,omitempty"`
+	ProviderTokens   *ProviderTokens `json:"providerTokens,omitempty"`
 }
 
 type EnchantedLinkResponse struct {
@@ -148,10 +285,11 @@ func NewAuthenticationInfo(jRes *JWTResponse, sessionToken, refreshToken *Token)
 	}
 
 	return &AuthenticationInfo{
-		SessionToken: sessionToken,
-		RefreshToken: refreshToken,
-		User:         jRes.User,
-		FirstSeen:    jRes.FirstSeen,
+		SessionToken:   sessionToken,
+		RefreshToken:   refreshToken,
+		User:           jRes.User,
+		FirstSeen:      jRes.FirstSeen,
+		ProviderTokens: jRes.ProviderTokens,
 	}
 }
 
@@ -185,26 +323,250 @@ type WebauthnUserRequest struct {
 }
 
 type UserResponse struct {
-	User          `json:",inline"`
-	UserID        string              `json:"userId,omitempty"`
-	LoginIDs      []string            `json:"loginIds,omitempty"`
-	VerifiedEmail bool                `json:"verifiedEmail,omitempty"`
-	VerifiedPhone bool                `json:"verifiedPhone,omitempty"`
-	RoleNames     []string            `json:"roleNames,omitempty"`
-	UserTenants   []*AssociatedTenant `json:"userTenants,omitempty"`
-	Status        string              `json:"status,omitempty"`
-	Picture       string              `json:"picture,omitempty"`
+	User             `json:",inline"`
+	UserID           string              `json:"userId,omitempty"`
+	LoginIDs         []string            `json:"loginIds,omitempty"`
+	VerifiedEmail    bool                `json:"verifiedEmail,omitempty"`
+	VerifiedPhone    bool                `json:"verifiedPhone,omitempty"`
+	RoleNames        []string            `json:"roleNames,omitempty"`
+	UserTenants      []*AssociatedTenant `json:"userTenants,omitempty"`
+	Status           string              `json:"status,omitempty"`
+	Picture          string              `json:"picture,omitempty"`
+	CustomAttributes CustomAttributes    `json:"customAttributes,omitempty"`
+}
+
+// The values of custom attributes for a user, keyed by the attribute name. The
+// typed getters return false when an attribute isn't set or has a different type.
+type CustomAttributes map[string]any
+
+func (ca CustomAttributes) String(key string) (string, bool) {
+	value, ok := ca[key].(string)
+	return value, ok
+}
+
+func (ca CustomAttributes) Bool(key string) (bool, bool) {
+	value, ok := ca[key].(bool)
+	return value, ok
+}
+
+// Number returns the value of a numeric attribute. Values decoded from JSON are
+// always float64, but other numeric types are converted as well.
+func (ca CustomAttributes) Number(key string) (float64, bool) {
+	switch value := ca[key].(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+// Int returns the value of a numeric attribute that has no fractional part.
+func (ca CustomAttributes) Int(key string) (int64, bool) {
+	value, ok := ca.Number(key)
+	if !ok || value != float64(int64(value)) {
+		return 0, false
+	}
+	return int64(value), true
+}
+
+// Strings returns the value of a multi-select or list attribute.
+func (ca CustomAttributes) Strings(key string) ([]string, bool) {
+	switch value := ca[key].(type) {
+	case []string:
+		return value, true
+	case []any:
+		list := []string{}
+		for _, item := range value {
+			str, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, str)
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// Fields of a user for creating or patching it. Fields that are nil aren't sent,
+// so patching a user only changes the fields that are set. Setting a list or map
+// field to an empty non-nil value clears it.
+type UserRequest struct {
+	Email              *string
+	Phone              *string
+	DisplayName        *string
+	Picture            *string
+	VerifiedEmail      *bool
+	VerifiedPhone      *bool
+	Status             *UserStatus
+	Roles              []string
+	Tenants            []*AssociatedTenant
+	CustomAttributes   map[string]any
+	AdditionalLoginIDs []string
+}
+
+// Ptr returns a pointer to the given value, for setting the optional fields in
+// request types such as UserRequest.
+func Ptr[T any](value T) *T {
+	return &value
+}
+
+type UserStatus string
+
+const (
+	UserStatusEnabled  UserStatus = "enabled"
+	UserStatusDisabled UserStatus = "disabled"
+	UserStatusInvited  UserStatus = "invited"
+)
+
+// Represents a sort order on a single user field when searching users.
+type UserSearchSort struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// Options for searching users. All fields are optional, and filters with
+// multiple values match users that have any of those values.
+type UserSearchOptions struct {
+	// The page to return, starting from 0.
+	Page int32 `json:"page,omitempty"`
+	// The maximum number of users in each page. Leave at 0 to return the default amount.
+	Limit int32 `json:"limit,omitempty"`
+	// Only return users that belong to any of these tenants.
+	TenantIDs []string `json:"tenantIds,omitempty"`
+	// Only return users that have any of these roles.
+	Roles []string `json:"roleNames,omitempty"`
+	// Only return users that have any of these login IDs.
+	LoginIDs []string `json:"loginIds,omitempty"`
+	// Only return users with any of these email addresses.
+	Emails []string `json:"emails,omitempty"`
+	// Only return users with any of these phone numbers.
+	Phones []string `json:"phones,omitempty"`
+	// A free text search on the users' login IDs, email addresses, phone numbers and names.
+	Text string `json:"text,omitempty"`
+	// Only return users with any of these statuses.
+	Statuses []UserStatus `json:"statuses,omitempty"`
+	// Only return users whose custom attributes match all of these values.
+	CustomAttributes map[string]any `json:"customAttributes,omitempty"`
+	// The order in which users are returned.
+	Sort []UserSearchSort `json:"sort,omitempty"`
+}
+
+type UserBatchOperation string
+
+const (
+	UserBatchCreate            UserBatchOperation = "create"
+	UserBatchUpdate            UserBatchOperation = "update"
+	UserBatchDelete            UserBatchOperation = "delete"
+	UserBatchAddRoles          UserBatchOperation = "addRoles"
+	UserBatchRemoveRoles       UserBatchOperation = "removeRoles"
+	UserBatchAddTenant         UserBatchOperation = "addTenant"
+	UserBatchRemoveTenant      UserBatchOperation = "removeTenant"
+	UserBatchAddTenantRoles    UserBatchOperation = "addTenantRoles"
+	UserBatchRemoveTenantRoles UserBatchOperation = "removeTenantRoles"
+)
+
+// A single operation on a user in a batch. The LoginID is always required, and
+// the other fields are used according to the operation, following the parameters
+// of the matching function in the User management interface.
+type UserBatchItem struct {
+	Operation        UserBatchOperation  `json:"operation"`
+	LoginID          string              `json:"loginId"`
+	Email            string              `json:"email,omitempty"`
+	Phone            string              `json:"phone,omitempty"`
+	DisplayName      string              `json:"displayName,omitempty"`
+	Roles            []string            `json:"roleNames,omitempty"`
+	Tenants          []*AssociatedTenant `json:"userTenants,omitempty"`
+	TenantID         string              `json:"tenantId,omitempty"`
+	CustomAttributes map[string]any      `json:"customAttributes,omitempty"`
+}
+
+// Options for running a batch of user operations.
+type UserBatchOptions struct {
+	// The number of operations that are performed concurrently. Defaults to 4.
+	Workers int
+	// How many times an operation is retried after failing due to rate limits,
+	// after waiting for the period requested by the server. Defaults to 3, and
+	// can be set to a negative value to disable retries.
+	MaxRetries int
+	// An optional path to a checkpoint file. The batch records each successful
+	// operation in this file, and when started again with the same items and
+	// checkpoint file it skips the operations that were already performed.
+	CheckpointFile string
+	// An optional callback that's called with the result of each operation as
+	// soon as it's done. Calls are never made concurrently.
+	OnResult func(result *UserBatchResult)
+}
+
+// The result of a single operation in a batch.
+type UserBatchResult struct {
+	// The index of the operation in the batch.
+	Index int
+	// The batch operation.
+	Item *UserBatchItem
+	// The updated user, for operations that return one.
+	User *UserResponse
+	// The error if the operation failed.
+	Err error
+	// Set when the operation was skipped since it was already performed according
+	// to the checkpoint file.
+	Skipped bool
+}
+
+// The fields of an access key when creating or updating it with options.
+type AccessKeyRequest struct {
+	// The name of the access key, which is required and doesn't have to be unique
+	Name string
+	// An optional description of what the key is for or who owns it
+	Description *string
+	// When the key should expire in seconds since the epoch, or 0 to make it indefinite.
+	// Only used when creating the key.
+	ExpireTime int64
+	// The roles of an access key that isn't associated with a tenant
+	RoleNames []string
+	// The tenants the access key is associated with and its roles in each one
+	KeyTenants []*AssociatedTenant
+	// Claims that are added to the JWT created when exchanging the access key
+	CustomClaims map[string]any
+	// IP addresses or CIDR ranges the key can be used from, any address is allowed when empty
+	PermittedIPs []string
 }
 
 type AccessKeyResponse struct {
-	ID          string              `json:"id,omitempty"`
-	Name        string              `json:"name,omitempty"`
-	RoleNames   []string            `json:"roleNames,omitempty"`
-	KeyTenants  []*AssociatedTenant `json:"keyTenants,omitempty"`
-	Status      string              `json:"status,omitempty"`
-	CreatedTime int32               `json:"createdTime,omitempty"`
-	ExpireTime  int32               `json:"expireTime,omitempty"`
-	CreatedBy   string              `json:"createdBy,omitempty"`
+	ID           string              `json:"id,omitempty"`
+	Name         string              `json:"name,omitempty"`
+	Description  string              `json:"description,omitempty"`
+	RoleNames    []string            `json:"roleNames,omitempty"`
+	KeyTenants   []*AssociatedTenant `json:"keyTenants,omitempty"`
+	Status       string              `json:"status,omitempty"`
+	CreatedTime  int64               `json:"createdTime,omitempty"`
+	ExpireTime   int64               `json:"expireTime,omitempty"`
+	CreatedBy    string              `json:"createdBy,omitempty"`
+	CustomClaims map[string]any      `json:"customClaims,omitempty"`
+	PermittedIPs []string            `json:"permittedIps,omitempty"`
+}
+
+// The result of rotating an access key. The rotation isn't recorded on the access keys
+// themselves, so when there's a grace period the caller should store the rotation until
+// the old access key is deactivated, e.g., in RotationPlanner.Pending. The cleartext is
+// left out when the rotation is serialized to JSON.
+type AccessKeyRotation struct {
+	// The cleartext of the new access key, which is only available at this point
+	Cleartext string `json:"-"`
+	// The new access key, with the same name, roles and tenants as the old one
+	NewKey *AccessKeyResponse `json:"newKey,omitempty"`
+	// The rotated access key as it was before the rotation
+	OldKey *AccessKeyResponse `json:"oldKey,omitempty"`
+	// When the old access key should be deactivated, which is done immediately by Rotate when
+	// there's no grace period, and otherwise by the caller, e.g., with RotationPlanner
+	DeactivateAt time.Time `json:"deactivateAt"`
 }
 
 // Represents a tenant association for a User or an Access Key. The tenant ID is required
@@ -229,10 +591,103 @@ type AttributeMapping struct {
 	Group       string `json:"group,omitempty"`
 }
 
+// Represents the SSO settings of a tenant
+type SSOSettingsResponse struct {
+	// The tenant the settings belong to
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// The SAML identity provider settings, empty when the tenant uses OIDC
+	IdpURL         string `json:"idpSSOUrl,omitempty"`
+	IdpEntityID    string `json:"idpEntityId,omitempty"`
+	IdpCertificate string `json:"idpCertificate,omitempty"`
+	IdpMetadataURL string `json:"idpMetadataUrl,omitempty"`
+	// The expiry date of the IdP certificate, or the zero time if there's no valid certificate
+	IdpCertificateExpiry time.Time `json:"-"`
+	// The OIDC identity provider settings, nil when the tenant uses SAML
+	OIDC             *OIDCSettings     `json:"oidc,omitempty"`
+	RoleMappings     []*RoleMapping    `json:"roleMappings,omitempty"`
+	AttributeMapping *AttributeMapping `json:"attributeMapping,omitempty"`
+	RedirectURL      string            `json:"redirectUrl,omitempty"`
+}
+
+// Represents the settings of an OIDC identity provider used for SSO in a tenant
+type OIDCSettings struct {
+	// A display name for the identity provider, e.g., "Okta"
+	Name string `json:"name"`
+	// The issuer of the tokens created by the identity provider
+	Issuer string `json:"issuer"`
+	// The client credentials of the application registered with the identity provider
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	// The scopes to request, defaults to "openid", "profile" and "email" when empty
+	Scopes []string `json:"scopes,omitempty"`
+	// The OpenID Connect discovery URL, usually ending with "/.well-known/openid-configuration"
+	DiscoveryURL string `json:"discoveryUrl,omitempty"`
+	// An optional URL to redirect to after the authentication, overriding the project setting
+	RedirectURL string `json:"redirectUrl,omitempty"`
+	// Maps claims in the identity provider's ID token to the Descope user attributes
+	AttributeMapping *OIDCAttributeMapping `json:"attributeMapping,omitempty"`
+}
+
+// Represents a mapping between Descope user attributes and OIDC claims
+type OIDCAttributeMapping struct {
+	LoginID       string `json:"loginId,omitempty"`
+	Name          string `json:"name,omitempty"`
+	GivenName     string `json:"givenName,omitempty"`
+	FamilyName    string `json:"familyName,omitempty"`
+	Email         string `json:"email,omitempty"`
+	VerifiedEmail string `json:"verifiedEmail,omitempty"`
+	PhoneNumber   string `json:"phoneNumber,omitempty"`
+	VerifiedPhone string `json:"verifiedPhone,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+	Group         string `json:"groups,omitempty"`
+}
+
 type Tenant struct {
-	ID                      string   `json:"id"`
-	Name                    string   `json:"name"`
-	SelfProvisioningDomains []string `json:"selfProvisioningDomains"`
+	ID                      string           `json:"id"`
+	Name                    string           `json:"name"`
+	SelfProvisioningDomains []string         `json:"selfProvisioningDomains"`
+	CustomAttributes        CustomAttributes `json:"customAttributes,omitempty"`
+}
+
+// The fields of a tenant when creating or updating it with options.
+type TenantRequest struct {
+	Name                    string
+	SelfProvisioningDomains []string
+	// Optional values for the tenant custom attributes, whose keys must be defined in
+	// the project's tenant custom attributes
+	CustomAttributes map[string]any
+}
+
+// Options for searching tenants, all fields are optional and tenants must match
+// all of the fields that are set.
+type TenantSearchOptions struct {
+	IDs                     []string
+	Names                   []string
+	SelfProvisioningDomains []string
+	CustomAttributes        map[string]any
+}
+
+// Settings that override the project settings for users in a tenant. When configuring
+// the settings, only the fields that are set are changed.
+type TenantSettings struct {
+	// When true the session settings below are used instead of the project ones
+	SessionSettingsEnabled *bool `json:"sessionSettingsEnabled,omitempty"`
+	// The expiration of session and refresh tokens, with units being one of
+	// "minutes", "hours", "days" or "weeks"
+	SessionTokenExpiration     *int    `json:"sessionTokenExpiration,omitempty"`
+	SessionTokenExpirationUnit *string `json:"sessionTokenExpirationUnit,omitempty"`
+	RefreshTokenExpiration     *int    `json:"refreshTokenExpiration,omitempty"`
+	RefreshTokenExpirationUnit *string `json:"refreshTokenExpirationUnit,omitempty"`
+	// Logs out users that are inactive for the given amount of time
+	EnableInactivity   *bool   `json:"enableInactivity,omitempty"`
+	InactivityTime     *int    `json:"inactivityTime,omitempty"`
+	InactivityTimeUnit *string `json:"inactivityTimeUnit,omitempty"`
+	// The authentication methods tenant users can sign in with, e.g., "otp" or "sso",
+	// all project methods are allowed when empty. Not changed when nil.
+	AuthMethods []string `json:"authMethods,omitempty"`
+	// When true only users with an email in one of the tenant's self provisioning
+	// domains can be associated with the tenant
+	EnforceDomains *bool `json:"enforceDomains,omitempty"`
 }
 
 type Permission struct {
@@ -258,6 +713,100 @@ type Group struct {
 	Members []GroupMember `json:"members,omitempty"`
 }
 
+// The changes applied by a call to SyncGroups, listed by group ID.
+type GroupSyncResult struct {
+	Created        []string `json:"created,omitempty"`
+	Updated        []string `json:"updated,omitempty"`
+	Deleted        []string `json:"deleted,omitempty"`
+	MembersChanged []string `json:"membersChanged,omitempty"`
+}
+
+// Options for searching the project's audit events. All fields are optional, and when
+// a filter is set only records that match any of its values are returned.
+type AuditSearchOptions struct {
+	// Only return records for these user IDs.
+	UserIDs []string `json:"userIds,omitempty"`
+	// Only return records for these login IDs.
+	LoginIDs []string `json:"loginIds,omitempty"`
+	// Only return records for these tenants.
+	Tenants []string `json:"tenants,omitempty"`
+	// Only return records with these actions, e.g., "LoginSucceed".
+	Actions []string `json:"actions,omitempty"`
+	// Only return records made from these IP addresses.
+	RemoteAddresses []string `json:"remoteAddresses,omitempty"`
+	// Only return records that occurred at or after this time.
+	From time.Time `json:"-"`
+	// Only return records that occurred before this time.
+	To time.Time `json:"-"`
+	// The maximum number of records in each page. Leave at 0 to return the default amount.
+	Limit int32 `json:"limit,omitempty"`
+}
+
+// A single audit event, as returned by searching the audit log.
+type AuditRecord struct {
+	ProjectID     string         `json:"projectId,omitempty"`
+	UserID        string         `json:"userId,omitempty"`
+	Action        string         `json:"action,omitempty"`
+	Occurred      time.Time      `json:"occurred"`
+	Device        string         `json:"device,omitempty"`
+	Method        string         `json:"method,omitempty"`
+	Geo           string         `json:"geo,omitempty"`
+	RemoteAddress string         `json:"remoteAddress,omitempty"`
+	LoginIDs      []string       `json:"loginIds,omitempty"`
+	Tenants       []string       `json:"tenants,omitempty"`
+	Data          map[string]any `json:"data,omitempty"`
+}
+
+// The details of a flow in the project, as returned by listing flows.
+type FlowMetadata struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// The version of the snapshot format returned by exporting a project. Snapshots with
+// a newer version can't be imported by this version of the SDK.
+const ProjectSnapshotVersion = 1
+
+// A versioned snapshot of a project's configuration, used to copy settings between
+// projects, e.g., when promoting changes from a staging project to production.
+//
+// The entities in each section are sorted by their name or ID, so that exporting
+// the same configuration twice produces the same JSON output.
+type ProjectSnapshot struct {
+	Version     int                    `json:"version"`
+	Permissions []*Permission          `json:"permissions,omitempty"`
+	Roles       []*Role                `json:"roles,omitempty"`
+	Tenants     []*Tenant              `json:"tenants,omitempty"`
+	SSOSettings []*SSOSettingsResponse `json:"ssoSettings,omitempty"`
+	// The flow definitions, keyed by flow ID
+	Flows map[string]any `json:"flows,omitempty"`
+}
+
+// Options for importing a project snapshot.
+type ProjectImportOptions struct {
+	// Only return the changes the import would make, without applying them.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Delete the permissions, roles and tenants that aren't in the snapshot. When not set
+	// the import only creates and updates entities, and never deletes any.
+	Prune bool `json:"prune,omitempty"`
+}
+
+// A single change made, or that would be made in a dry run, by importing a project snapshot.
+type ProjectImportChange struct {
+	// The type of the changed entity, e.g., "role", "tenant", "sso" or "flow"
+	Entity string `json:"entity"`
+	// The name or ID of the changed entity
+	ID string `json:"id"`
+	// One of "create", "update" or "delete"
+	Action string `json:"action"`
+}
+
+func (c *ProjectImportChange) String() string {
+	return fmt.Sprintf("%s %s %s", c.Action, c.Entity, c.ID)
+}
+
 type DeliveryMethod string
 
 type OAuthProvider string
@@ -275,15 +824,25 @@ const (
 	OAuthMicrosoft OAuthProvider = "microsoft"
 	OAuthGitlab    OAuthProvider = "gitlab"
 	OAuthApple     OAuthProvider = "apple"
+	OAuthSlack     OAuthProvider = "slack"
+	OAuthDiscord   OAuthProvider = "discord"
+	OAuthLinkedIn  OAuthProvider = "linkedin"
+	OAuthTwitter   OAuthProvider = "twitter" // Twitter/X
 
 	SessionCookieName = "DS"
 	RefreshCookieName = "DSR"
 
+	// The cookie that holds the OAuth state between starting an OAuth authentication
+	// and exchanging the code, and the query parameter it's sent back in
+	OAuthStateCookieName = "DSOS"
+	OAuthStateParamName  = "state"
+
 	RedirectLocationCookieName = "Location"
 
 	ContextUserIDProperty               = "DESCOPE_USER_ID"
 	ContextUserIDPropertyKey ContextKey = ContextUserIDProperty
 	ClaimAuthorizedTenants              = "tenants"
+	ClaimActor                          = "act"
 
 	EnvironmentVariableProjectID     = "DESCOPE_PROJECT_ID"
 	EnvironmentVariablePublicKey     = "DESCOPE_PUBLIC_KEY"
@@ -38,6 +38,78 @@ func TestCustomClaims(t *testing.T) {
 	assert.Nil(t, to.CustomClaim("a"))
 }
 
+func TestImpersonator(t *testing.T) {
+	to := &Token{}
+	assert.Empty(t, to.Impersonator())
+	assert.False(t, to.IsImpersonated())
+	to = &Token{Claims: map[string]interface{}{ClaimActor: "foo"}}
+	assert.False(t, to.IsImpersonated())
+	to = &Token{Claims: map[string]interface{}{ClaimActor: map[string]interface{}{"sub": "support"}}}
+	assert.Equal(t, "support", to.Impersonator())
+	assert.True(t, to.IsImpersonated())
+}
+
+func TestCustomAttributes(t *testing.T) {
+	ca := CustomAttributes{
+		"department": "R&D",
+		"manager":    true,
+		"employeeId": float64(42),
+		"score":      4.5,
+		"level":      3,
+		"teams":      []any{"a", "b"},
+		"mixed":      []any{"a", 1},
+		"tags":       []string{"x"},
+	}
+	s, ok := ca.String("department")
+	assert.True(t, ok)
+	assert.Equal(t, "R&D", s)
+	_, ok = ca.String("manager")
+	assert.False(t, ok)
+
+	b, ok := ca.Bool("manager")
+	assert.True(t, ok)
+	assert.True(t, b)
+	_, ok = ca.Bool("missing")
+	assert.False(t, ok)
+
+	n, ok := ca.Number("score")
+	assert.True(t, ok)
+	assert.Equal(t, 4.5, n)
+	n, ok = ca.Number("level")
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), n)
+	_, ok = ca.Number("department")
+	assert.False(t, ok)
+
+	i, ok := ca.Int("employeeId")
+	assert.True(t, ok)
+	assert.EqualValues(t, 42, i)
+	_, ok = ca.Int("score")
+	assert.False(t, ok)
+
+	l, ok := ca.Strings("teams")
+	assert.True(t, ok)
+	assert.EqualValues(t, []string{"a", "b"}, l)
+	l, ok = ca.Strings("tags")
+	assert.True(t, ok)
+	assert.EqualValues(t, []string{"x"}, l)
+	_, ok = ca.Strings("mixed")
+	assert.False(t, ok)
+	_, ok = ca.Strings("department")
+	assert.False(t, ok)
+
+	var empty CustomAttributes
+	_, ok = empty.String("department")
+	assert.False(t, ok)
+
+	to := &Token{Claims: map[string]any{"department": "R&D", "sub": "u1", "exp": float64(1), "drn": "DS", "roles": []any{"admin"}, "tenants": map[string]any{}, "act": map[string]any{"sub": "u2"}}}
+	s, ok = to.CustomClaims().String("department")
+	assert.True(t, ok)
+	assert.Equal(t, "R&D", s)
+	assert.Equal(t, CustomAttributes{"department": "R&D"}, to.CustomClaims())
+	assert.Empty(t, (&Token{}).CustomClaims())
+}
+
 func TestNewToken(t *testing.T) {
 	jwtStr := "jwtttt"
 	token := jwt.New()
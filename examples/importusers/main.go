@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/client"
+	"github.com/descope/go-sdk/descope/migrate"
 )
 
 var descopeClient *client.DescopeClient
@@ -43,9 +45,58 @@ type Data struct {
 	Users []*User `json:"users"`
 }
 
+// Reads the users from a JSON file with the format in example.json
+func readJSONFile(path string) ([]*descope.UserBatchItem, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data := Data{}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return nil, err
+	}
+
+	items := []*descope.UserBatchItem{}
+	for _, user := range data.Users {
+		tenants := []*descope.AssociatedTenant{}
+		for _, curr := range user.Tenants {
+			tenants = append(tenants, &descope.AssociatedTenant{TenantID: curr.TenantID, Roles: curr.Roles})
+		}
+		items = append(items, &descope.UserBatchItem{
+			Operation:   descope.UserBatchCreate,
+			LoginID:     user.LoginID,
+			Email:       user.Email,
+			Phone:       user.Phone,
+			DisplayName: user.DisplayName,
+			Roles:       user.Roles,
+			Tenants:     tenants,
+		})
+	}
+	return items, nil
+}
+
+// Reads the users from a CSV or JSONL file, skipping any invalid records
+func readMigrateFile(path string, format migrate.Format) ([]*descope.UserBatchItem, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	res, err := migrate.Read(file, &migrate.Options{Format: format})
+	if err != nil {
+		return nil, err
+	}
+	for _, invalid := range res.Invalid {
+		fmt.Fprintln(os.Stderr, "Skipping invalid user at", invalid)
+	}
+	return res.BatchItems(), nil
+}
+
 func main() {
 	if len(os.Args) != 2 || len(os.Args[1]) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: importusers <jsonfile>")
+		fmt.Fprintln(os.Stderr, "Usage: importusers <file.json|file.csv|file.jsonl>")
 		os.Exit(1)
 	}
 
@@ -54,33 +105,51 @@ func main() {
 		os.Exit(1)
 	}
 
-	bytes, err := os.ReadFile(os.Args[1])
+	var items []*descope.UserBatchItem
+	var err error
+	switch filepath.Ext(os.Args[1]) {
+	case ".csv":
+		items, err = readMigrateFile(os.Args[1], migrate.FormatCSV)
+	case ".jsonl":
+		items, err = readMigrateFile(os.Args[1], migrate.FormatJSONL)
+	default:
+		items, err = readJSONFile(os.Args[1])
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error reading input file:", err)
 		os.Exit(1)
 	}
 
-	data := Data{}
-	if err := json.Unmarshal(bytes, &data); err != nil {
-		fmt.Fprintln(os.Stderr, "Error parsing input file:", err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Adding", len(data.Users), "users...")
+	fmt.Println("Adding", len(items), "users...")
 
-	for _, user := range data.Users {
-		fmt.Println("Adding user", user.LoginID, "("+user.DisplayName+")")
+	// the checkpoint file lets the import be resumed if it's interrupted or some users fail
+	options := &descope.UserBatchOptions{
+		CheckpointFile: os.Args[1] + ".checkpoint",
+		OnResult: func(result *descope.UserBatchResult) {
+			if result.Skipped {
+				fmt.Println("Skipped user", result.Item.LoginID, "(already added)")
+			} else if result.Err != nil {
+				fmt.Fprintln(os.Stderr, "Error adding user", result.Item.LoginID+":", result.Err)
+			} else {
+				fmt.Printf("Added user: %v\n", result.User)
+			}
+		},
+	}
 
-		tenants := []*descope.AssociatedTenant{}
-		for _, curr := range user.Tenants {
-			tenants = append(tenants, &descope.AssociatedTenant{TenantID: curr.TenantID, Roles: curr.Roles})
-		}
+	results, err := descopeClient.Management.User().Batch(items, options)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error running batch:", err)
+		os.Exit(1)
+	}
 
-		res, err := descopeClient.Management.User().Create(user.LoginID, user.Email, user.Phone, user.DisplayName, user.Roles, tenants)
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "Error adding user:", err)
-		} else {
-			fmt.Printf("Added user: %v\n", res)
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
 		}
 	}
+	if failed > 0 {
+		fmt.Fprintln(os.Stderr, "Failed to add", failed, "users, run the import again to retry them")
+		os.Exit(1)
+	}
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -9,20 +10,24 @@ import (
 
 	"github.com/descope/go-sdk/descope"
 	"github.com/descope/go-sdk/descope/client"
+	"github.com/descope/go-sdk/descope/config"
 	"github.com/spf13/cobra"
 )
 
 // Command line flags
 
 var flags struct {
-	LoginID     string
-	Email       string
-	Phone       string
-	Name        string
-	Tenants     []string
-	Domains     []string
-	Description string
-	Permissions []string
+	LoginID      string
+	Email        string
+	Phone        string
+	Name         string
+	Tenants      []string
+	Domains      []string
+	Description  string
+	Permissions  []string
+	PermittedIPs []string
+	DryRun       bool
+	Prune        bool
 }
 
 // Descope SDK
@@ -91,7 +96,11 @@ func accessKeyCreate(args []string) error {
 	if err != nil {
 		return err
 	}
-	cleartext, res, err := descopeClient.Management.AccessKey().Create(args[0], expireTime, nil, tenants)
+	key := &descope.AccessKeyRequest{Name: args[0], ExpireTime: expireTime, KeyTenants: tenants, PermittedIPs: flags.PermittedIPs}
+	if flags.Description != "" {
+		key.Description = &flags.Description
+	}
+	cleartext, res, err := descopeClient.Management.AccessKey().CreateWithOptions(key)
 	if err != nil {
 		return err
 	}
@@ -119,7 +128,11 @@ func accessKeySearchAll(args []string) error {
 }
 
 func accessKeyUpdate(args []string) error {
-	_, err := descopeClient.Management.AccessKey().Update(args[0], args[1])
+	key := &descope.AccessKeyRequest{Name: args[1]}
+	if flags.Description != "" {
+		key.Description = &flags.Description
+	}
+	_, err := descopeClient.Management.AccessKey().UpdateWithOptions(args[0], key)
 	return err
 }
 
@@ -258,6 +271,132 @@ func groupAllGroupMembers(args []string) error {
 	return err
 }
 
+func configPlan(args []string) error {
+	desired, err := config.Load(args[0])
+	if err != nil {
+		return err
+	}
+	plan, err := config.NewPlan(descopeClient.Management, desired)
+	if err == nil {
+		fmt.Println(plan)
+	}
+	return err
+}
+
+func configApply(args []string) error {
+	desired, err := config.Load(args[0])
+	if err != nil {
+		return err
+	}
+	plan, err := config.NewPlan(descopeClient.Management, desired)
+	if err != nil {
+		return err
+	}
+	if plan.IsEmpty() {
+		fmt.Println(plan)
+		return nil
+	}
+	_, err = config.Apply(descopeClient.Management, plan, &config.ApplyOptions{
+		DryRun: flags.DryRun,
+		OnChange: func(change *config.Change) {
+			fmt.Println(change)
+		},
+	})
+	return err
+}
+
+func projectExport(args []string) error {
+	snapshot, err := descopeClient.Management.Project().Export()
+	if err != nil {
+		return err
+	}
+	return writeJSON(snapshot, args, 0)
+}
+
+func projectImport(args []string) error {
+	snapshot := &descope.ProjectSnapshot{}
+	if err := readJSON(args[0], snapshot); err != nil {
+		return err
+	}
+	changes, err := descopeClient.Management.Project().Import(snapshot, &descope.ProjectImportOptions{DryRun: flags.DryRun, Prune: flags.Prune})
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+	}
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	return nil
+}
+
+func flowList(args []string) error {
+	res, err := descopeClient.Management.Flow().ListFlows()
+	if err == nil {
+		for _, f := range res {
+			fmt.Printf("Found flow: %s, %s. Disabled: %t\n", f.ID, f.Name, f.Disabled)
+		}
+	}
+	return err
+}
+
+func flowExport(args []string) error {
+	flowJSON, err := descopeClient.Management.Flow().ExportFlow(args[0])
+	if err != nil {
+		return err
+	}
+	return writeJSON(flowJSON, args, 1)
+}
+
+func flowImport(args []string) error {
+	flowJSON := map[string]any{}
+	if err := readJSON(args[1], &flowJSON); err != nil {
+		return err
+	}
+	return descopeClient.Management.Flow().ImportFlow(args[0], flowJSON)
+}
+
+func themeExport(args []string) error {
+	theme, err := descopeClient.Management.Flow().ExportTheme()
+	if err != nil {
+		return err
+	}
+	return writeJSON(theme, args, 0)
+}
+
+func themeImport(args []string) error {
+	theme := map[string]any{}
+	if err := readJSON(args[0], &theme); err != nil {
+		return err
+	}
+	return descopeClient.Management.Flow().ImportTheme(theme)
+}
+
+// Writes the value as pretty-printed JSON to the file in args at the given index, or to
+// the standard output if there's no such argument. Map keys are always marshaled in order,
+// so the same value is always written the same way and the files can be kept in git.
+func writeJSON(v any, args []string, index int) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if len(args) <= index {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(args[index], b, 0600)
+}
+
+func readJSON(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
 // Command line setup
 
 var cli = &cobra.Command{
@@ -309,6 +448,8 @@ func main() {
 	addCommand(accessKeyCreate, "access-key-create <name> <expireTime>", "Create a new access key", func(cmd *cobra.Command) {
 		cmd.Args = cobra.ExactArgs(2)
 		cmd.Flags().StringSliceVarP(&flags.Tenants, "tenants", "T", nil, "the ids of the user's tenants")
+		cmd.Flags().StringVarP(&flags.Description, "description", "D", "", "the access key's description")
+		cmd.Flags().StringSliceVarP(&flags.PermittedIPs, "permitted-ips", "I", nil, "the IP addresses or CIDR ranges the access key can be used from")
 	})
 
 	addCommand(accessKeyLoad, "access-key-load", "Load an access key <id>", func(cmd *cobra.Command) {
@@ -322,7 +463,7 @@ func main() {
 
 	addCommand(accessKeyUpdate, "access-key-update", "Update an access key <id>", func(cmd *cobra.Command) {
 		cmd.Args = cobra.ExactArgs(2)
-		cmd.DisableFlagsInUseLine = true
+		cmd.Flags().StringVarP(&flags.Description, "description", "D", "", "the access key's description")
 	})
 
 	addCommand(accessKeyDeactivate, "access-key-deactivate", "Deactivate an access key <id>", func(cmd *cobra.Command) {
@@ -430,6 +571,51 @@ func main() {
 		cmd.DisableFlagsInUseLine = true
 	})
 
+	addCommand(configPlan, "plan <file>", "Show the changes required to match the permissions, roles and tenants in a configuration file", func(cmd *cobra.Command) {
+		cmd.Args = cobra.ExactArgs(1)
+		cmd.DisableFlagsInUseLine = true
+	})
+
+	addCommand(configApply, "apply <file>", "Apply the permissions, roles and tenants in a configuration file", func(cmd *cobra.Command) {
+		cmd.Args = cobra.ExactArgs(1)
+		cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "only print the changes without applying them")
+	})
+
+	addCommand(projectExport, "project-export [file]", "Export the project's configuration as JSON to a file or to the standard output", func(cmd *cobra.Command) {
+		cmd.Args = cobra.MaximumNArgs(1)
+		cmd.DisableFlagsInUseLine = true
+	})
+
+	addCommand(projectImport, "project-import <file>", "Import a project configuration exported by project-export", func(cmd *cobra.Command) {
+		cmd.Args = cobra.ExactArgs(1)
+		cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "only print the changes without applying them")
+		cmd.Flags().BoolVar(&flags.Prune, "prune", false, "delete permissions, roles and tenants that aren't in the file")
+	})
+
+	addCommand(flowList, "flow-list", "Load all flows", func(cmd *cobra.Command) {
+		cmd.DisableFlagsInUseLine = true
+	})
+
+	addCommand(flowExport, "flow-export <flowId> [file]", "Export a flow as JSON to a file or to the standard output", func(cmd *cobra.Command) {
+		cmd.Args = cobra.RangeArgs(1, 2)
+		cmd.DisableFlagsInUseLine = true
+	})
+
+	addCommand(flowImport, "flow-import <flowId> <file>", "Import a flow exported by flow-export, replacing it if it exists", func(cmd *cobra.Command) {
+		cmd.Args = cobra.ExactArgs(2)
+		cmd.DisableFlagsInUseLine = true
+	})
+
+	addCommand(themeExport, "theme-export [file]", "Export the project's theme as JSON to a file or to the standard output", func(cmd *cobra.Command) {
+		cmd.Args = cobra.MaximumNArgs(1)
+		cmd.DisableFlagsInUseLine = true
+	})
+
+	addCommand(themeImport, "theme-import <file>", "Import a theme exported by theme-export, replacing the project's theme", func(cmd *cobra.Command) {
+		cmd.Args = cobra.ExactArgs(1)
+		cmd.DisableFlagsInUseLine = true
+	})
+
 	err := cli.Execute()
 	if err != nil {
 		os.Exit(1)